@@ -1,9 +1,11 @@
-package json
+package luna
 
 import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
+	"time"
 )
 
 // Array provides methods to either navigate through the content of a JSON array or propagate any error that has occurred
@@ -13,24 +15,40 @@ type Array struct {
 	err  error
 }
 
-// ArrayFromBytes creates an Array from a []byte
+// ArrayFromBytes creates an Array from a []byte, using the default Decoder
 func ArrayFromBytes(jsonBytes []byte) Array {
-	var a Array
-	err := json.Unmarshal(jsonBytes, &a.a)
-	if err != nil {
+	return ArrayFromBytesWith(jsonBytes, defaultDecoder)
+}
+
+// ArrayFromBytesWith creates an Array from a []byte, decoded with the given Decoder
+func ArrayFromBytesWith(jsonBytes []byte, d Decoder) Array {
+	var v interface{}
+	if err := d.Unmarshal(jsonBytes, &v); err != nil {
 		return Array{nil, "$", err}
 	}
-	return a
+	return arrayFromValue(v)
 }
 
-// ArrayFromReader creates an Array from an io.Reader
+// ArrayFromReader creates an Array from an io.Reader, using the default Decoder
 func ArrayFromReader(r io.Reader) Array {
-	var a Array
-	err := json.NewDecoder(r).Decode(&a.a)
-	if err != nil {
+	return ArrayFromReaderWith(r, defaultDecoder)
+}
+
+// ArrayFromReaderWith creates an Array from an io.Reader, decoded with the given Decoder
+func ArrayFromReaderWith(r io.Reader, d Decoder) Array {
+	var v interface{}
+	if err := d.NewStreamDecoder(r).Decode(&v); err != nil {
 		return Array{nil, "$", err}
 	}
-	return a
+	return arrayFromValue(v)
+}
+
+func arrayFromValue(v interface{}) Array {
+	a, ok := v.([]interface{})
+	if !ok {
+		return Array{nil, "$", fmt.Errorf("decoded value was a %T, not an array", v)}
+	}
+	return Array{a, "$", nil}
 }
 
 // NewArray creates an Array from a []interface{}
@@ -111,7 +129,8 @@ func (a Array) String(idx int) (string, error) {
 	return s, nil
 }
 
-// Float returns the value of a float at index `idx` in the array, or a propagated error
+// Float returns the value of a float at index `idx` in the array, or a propagated error.
+// Accepts either a float64 (the default decoder) or a json.Number (the NumberDecoder).
 func (a Array) Float(idx int) (float64, error) {
 	if a.err != nil {
 		return 0.0, a.err
@@ -120,13 +139,118 @@ func (a Array) Float(idx int) (float64, error) {
 	if err != nil {
 		return 0.0, err
 	}
-	f, ok := a.a[idx].(float64)
+	f, ok := toFloat(a.a[idx])
 	if !ok {
 		return 0.0, fmt.Errorf("item at index %d was a %T, not a float", idx, a.a[idx])
 	}
 	return f, nil
 }
 
+// Int returns the value of an int at index `idx` in the array, or a propagated error
+func (a Array) Int(idx int) (int, error) {
+	f, err := a.Float(idx)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+// BigInt returns the value of an integer at index `idx` in the array as a *big.Int, or a
+// propagated error. Use this (together with NumberDecoder) instead of Int/Float to read
+// 64-bit ids losslessly, since float64 can't represent every int64 exactly.
+func (a Array) BigInt(idx int) (*big.Int, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if err := a.validateIndex(idx); err != nil {
+		return nil, err
+	}
+	bi, ok := toBigInt(a.a[idx])
+	if !ok {
+		return nil, fmt.Errorf("item at index %d was a %T, not an integer", idx, a.a[idx])
+	}
+	return bi, nil
+}
+
+// Number returns the value at index `idx` in the array as a json.Number, or a propagated error.
+func (a Array) Number(idx int) (json.Number, error) {
+	if a.err != nil {
+		return "", a.err
+	}
+	if err := a.validateIndex(idx); err != nil {
+		return "", err
+	}
+	n, ok := toNumber(a.a[idx])
+	if !ok {
+		return "", fmt.Errorf("item at index %d was a %T, not a number", idx, a.a[idx])
+	}
+	return n, nil
+}
+
+// Int64 returns the value of an int64 at index `idx` in the array, or a propagated error.
+// Accepts either a JSON number or a JSON string containing one, since many upstream APIs
+// quote large 64-bit ids to survive JavaScript's float64 range.
+func (a Array) Int64(idx int) (int64, error) {
+	if a.err != nil {
+		return 0, a.err
+	}
+	if err := a.validateIndex(idx); err != nil {
+		return 0, err
+	}
+	i, err := toInt64(a.a[idx])
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return i, nil
+}
+
+// Uint64 returns the value of a uint64 at index `idx` in the array, or a propagated error. See Int64.
+func (a Array) Uint64(idx int) (uint64, error) {
+	if a.err != nil {
+		return 0, a.err
+	}
+	if err := a.validateIndex(idx); err != nil {
+		return 0, err
+	}
+	u, err := toUint64(a.a[idx])
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return u, nil
+}
+
+// Time returns the value at index `idx` in the array as a time.Time, or a propagated error.
+// The value may be an RFC3339 string, or an epoch number given in seconds or microseconds.
+func (a Array) Time(idx int) (time.Time, error) {
+	if a.err != nil {
+		return time.Time{}, a.err
+	}
+	if err := a.validateIndex(idx); err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(a.a[idx])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return t, nil
+}
+
+// Duration returns the value at index `idx` in the array as a time.Duration, or a propagated
+// error. The value may be a Go duration string (e.g. "1h30m"), or a number of seconds.
+func (a Array) Duration(idx int) (time.Duration, error) {
+	if a.err != nil {
+		return 0, a.err
+	}
+	if err := a.validateIndex(idx); err != nil {
+		return 0, err
+	}
+	d, err := toDuration(a.a[idx])
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return d, nil
+}
+
 // Bool returns the value of a bool at index `idx` in the array, or a propagated error
 func (a Array) Bool(idx int) (bool, error) {
 	if a.err != nil {
@@ -148,7 +272,7 @@ func (a Array) Bytes() ([]byte, error) {
 	if a.err != nil {
 		return nil, a.err
 	}
-	buf, err := json.Marshal(a)
+	buf, err := json.Marshal(a.a)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +331,71 @@ func (a Array) MustFloat(idx int) float64 {
 	return f
 }
 
+// MustInt returns the value of an int at index `idx` in the array, or panics if there was an error
+func (a Array) MustInt(idx int) int {
+	i, err := a.Int(idx)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// MustBigInt returns the value of an integer at index `idx` in the array as a *big.Int, or
+// panics if there was an error
+func (a Array) MustBigInt(idx int) *big.Int {
+	bi, err := a.BigInt(idx)
+	if err != nil {
+		panic(err)
+	}
+	return bi
+}
+
+// MustNumber returns the value at index `idx` in the array as a json.Number, or panics if
+// there was an error
+func (a Array) MustNumber(idx int) json.Number {
+	n, err := a.Number(idx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// MustInt64 returns the value of an int64 at index `idx` in the array, or panics if there was an error
+func (a Array) MustInt64(idx int) int64 {
+	i, err := a.Int64(idx)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// MustUint64 returns the value of a uint64 at index `idx` in the array, or panics if there was an error
+func (a Array) MustUint64(idx int) uint64 {
+	u, err := a.Uint64(idx)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// MustTime returns the value at index `idx` in the array as a time.Time, or panics if there was an error
+func (a Array) MustTime(idx int) time.Time {
+	t, err := a.Time(idx)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// MustDuration returns the value at index `idx` in the array as a time.Duration, or panics if there was an error
+func (a Array) MustDuration(idx int) time.Duration {
+	d, err := a.Duration(idx)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
 // MustBool returns the value of a bool at index `idx` in the array, or panics if there was an error
 func (a Array) MustBool(idx int) bool {
 	if a.err != nil {
@@ -223,3 +412,9 @@ func (a Array) MustBool(idx int) bool {
 func (a Array) Err() error {
 	return a.err
 }
+
+// Path returns the JSONPath-style location of this array within the document it was read
+// from (e.g. "$['people']"), for callers building their own error messages around it.
+func (a Array) Path() string {
+	return string(a.path)
+}
@@ -0,0 +1,189 @@
+package luna
+
+import "fmt"
+
+// Builder constructs a JSON document fluently, e.g.
+//
+//	b := NewBuilder()
+//	m := b.Object().Key("id").Int(42).Key("tags").Array().String("a").String("b").End().End().Build()
+//
+// Errors (a Key with no following value, a value with no open Object/Array, ...) are recorded
+// internally and propagate through the rest of the chain, the same way Map/Array errors do.
+type Builder struct {
+	frames []*builderFrame
+	result interface{}
+	done   bool
+	err    error
+}
+
+type builderFrame struct {
+	isArray bool
+	m       map[string]interface{}
+	a       []interface{}
+	key     string
+	hasKey  bool
+}
+
+// NewBuilder creates an empty Builder, ready for a leading Object() or Array() call.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+func (b *Builder) fail(err error) *Builder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+func (b *Builder) top() *builderFrame {
+	if len(b.frames) == 0 {
+		return nil
+	}
+	return b.frames[len(b.frames)-1]
+}
+
+// attach places v at the current position: the pending key of an open Object, the next
+// element of an open Array, or the finished top-level value if no Object/Array is open.
+func (b *Builder) attach(v interface{}) {
+	f := b.top()
+	if f == nil {
+		b.result = v
+		b.done = true
+		return
+	}
+	if f.isArray {
+		f.a = append(f.a, v)
+		return
+	}
+	if !f.hasKey {
+		b.fail(fmt.Errorf("value set on an object with no preceding Key call"))
+		return
+	}
+	f.m[f.key] = v
+	f.hasKey = false
+}
+
+// Object opens a new, nested JSON object.
+func (b *Builder) Object() *Builder {
+	if b.err != nil {
+		return b
+	}
+	if b.done {
+		return b.fail(fmt.Errorf("builder already has a top-level value"))
+	}
+	b.frames = append(b.frames, &builderFrame{m: map[string]interface{}{}})
+	return b
+}
+
+// Array opens a new, nested JSON array.
+func (b *Builder) Array() *Builder {
+	if b.err != nil {
+		return b
+	}
+	if b.done {
+		return b.fail(fmt.Errorf("builder already has a top-level value"))
+	}
+	b.frames = append(b.frames, &builderFrame{isArray: true})
+	return b
+}
+
+// Key names the field the next value (or Object/Array) will be assigned to on the
+// currently-open Object.
+func (b *Builder) Key(name string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	f := b.top()
+	if f == nil || f.isArray {
+		return b.fail(fmt.Errorf("Key called with no open Object"))
+	}
+	if f.hasKey {
+		return b.fail(fmt.Errorf("Key %q set without a preceding value for Key %q", name, f.key))
+	}
+	f.key, f.hasKey = name, true
+	return b
+}
+
+// String adds a string value at the current position.
+func (b *Builder) String(v string) *Builder { return b.value(v) }
+
+// Int adds an int value at the current position, stored as a float64 like any other decoded
+// JSON number so Map.Int/Map.Float read it back the same way they would a parsed document.
+func (b *Builder) Int(v int) *Builder { return b.value(float64(v)) }
+
+// Float adds a float64 value at the current position.
+func (b *Builder) Float(v float64) *Builder { return b.value(v) }
+
+// Bool adds a bool value at the current position.
+func (b *Builder) Bool(v bool) *Builder { return b.value(v) }
+
+// Null adds a null value at the current position.
+func (b *Builder) Null() *Builder { return b.value(nil) }
+
+func (b *Builder) value(v interface{}) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if b.top() == nil {
+		return b.fail(fmt.Errorf("value set with no open Object or Array"))
+	}
+	b.attach(v)
+	return b
+}
+
+// End closes the innermost open Object or Array, attaching it to its enclosing Object/Array,
+// or recording it as the finished top-level value if it was the outermost one.
+func (b *Builder) End() *Builder {
+	if b.err != nil {
+		return b
+	}
+	f := b.top()
+	if f == nil {
+		return b.fail(fmt.Errorf("End called with no open Object or Array"))
+	}
+	if !f.isArray && f.hasKey {
+		return b.fail(fmt.Errorf("End called with Key %q set but no value", f.key))
+	}
+	b.frames = b.frames[:len(b.frames)-1]
+	if f.isArray {
+		b.attach(f.a)
+	} else {
+		b.attach(f.m)
+	}
+	return b
+}
+
+// Build finalizes the builder and returns the constructed value as a Map. It is an error to
+// call Build before every Object/Array has been closed with End, or if the finished value
+// isn't an object.
+func (b *Builder) Build() Map {
+	if b.err != nil {
+		return Map{nil, "$", b.err}
+	}
+	if !b.done {
+		return Map{nil, "$", fmt.Errorf("Build called with an unclosed Object or Array")}
+	}
+	m, ok := b.result.(map[string]interface{})
+	if !ok {
+		return Map{nil, "$", fmt.Errorf("built value was a %T, not an object; use BuildArray instead", b.result)}
+	}
+	return Map{m, "$", nil}
+}
+
+// BuildArray finalizes the builder and returns the constructed value as an Array. It is an
+// error to call BuildArray before every Object/Array has been closed with End, or if the
+// finished value isn't an array.
+func (b *Builder) BuildArray() Array {
+	if b.err != nil {
+		return Array{nil, "$", b.err}
+	}
+	if !b.done {
+		return Array{nil, "$", fmt.Errorf("BuildArray called with an unclosed Object or Array")}
+	}
+	a, ok := b.result.([]interface{})
+	if !ok {
+		return Array{nil, "$", fmt.Errorf("built value was a %T, not an array; use Build instead", b.result)}
+	}
+	return Array{a, "$", nil}
+}
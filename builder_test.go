@@ -0,0 +1,48 @@
+package luna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderNestedObjectAndArray(t *testing.T) {
+	b := NewBuilder()
+	m := b.Object().
+		Key("id").Int(42).
+		Key("tags").Array().String("a").String("b").End().
+		End().Build()
+
+	require.NoError(t, m.Err())
+	id, err := m.Int("id")
+	require.NoError(t, err)
+	require.Equal(t, 42, id)
+	tags := m.Array("tags").MustInner()
+	require.Equal(t, []interface{}{"a", "b"}, tags)
+}
+
+func TestBuilderArrayOfObjects(t *testing.T) {
+	b := NewBuilder()
+	a := b.Array().
+		Object().Key("name").String("Ada").End().
+		Object().Key("name").String("Grace").End().
+		End().BuildArray()
+
+	require.NoError(t, a.Err())
+	require.Equal(t, 2, a.MustLen())
+	name, err := a.Map(0).String("name")
+	require.NoError(t, err)
+	require.Equal(t, "Ada", name)
+}
+
+func TestBuilderKeyWithoutValueIsError(t *testing.T) {
+	b := NewBuilder()
+	m := b.Object().Key("id").End().Build()
+	require.Error(t, m.Err())
+}
+
+func TestBuilderBuildBeforeEndIsError(t *testing.T) {
+	b := NewBuilder()
+	m := b.Object().Key("id").Int(1).Build()
+	require.Error(t, m.Err())
+}
@@ -0,0 +1,58 @@
+package luna
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var snowflakeJSON = []byte(`{"id": 1475508451862012000, "count": 5}`)
+
+func TestFloatLosesPrecisionWithStdDecoder(t *testing.T) {
+	m := MapFromBytes(snowflakeJSON)
+	f, err := m.Float("id")
+	require.NoError(t, err)
+	require.NotEqual(t, "1475508451862012000", big.NewFloat(f).Text('f', 0))
+}
+
+func TestBigIntWithNumberDecoder(t *testing.T) {
+	m := MapFromBytesWith(snowflakeJSON, NumberDecoder())
+	bi, err := m.BigInt("id")
+	require.NoError(t, err)
+	require.Equal(t, "1475508451862012000", bi.String())
+}
+
+func TestNumberWithNumberDecoder(t *testing.T) {
+	m := MapFromBytesWith(snowflakeJSON, NumberDecoder())
+	n, err := m.Number("id")
+	require.NoError(t, err)
+	require.Equal(t, "1475508451862012000", n.String())
+}
+
+func TestIntStillWorksWithNumberDecoder(t *testing.T) {
+	m := MapFromBytesWith(snowflakeJSON, NumberDecoder())
+	i, err := m.Int("count")
+	require.NoError(t, err)
+	require.Equal(t, 5, i)
+}
+
+func TestSetDefaultDecoder(t *testing.T) {
+	SetDefaultDecoder(NumberDecoder())
+	defer SetDefaultDecoder(stdDecoder{})
+
+	m := MapFromBytes(snowflakeJSON)
+	bi, err := m.BigInt("id")
+	require.NoError(t, err)
+	require.Equal(t, "1475508451862012000", bi.String())
+}
+
+func TestArrayBigIntAndNumber(t *testing.T) {
+	a := ArrayFromBytesWith([]byte(`[1475508451862012000]`), NumberDecoder())
+	bi, err := a.BigInt(0)
+	require.NoError(t, err)
+	require.Equal(t, "1475508451862012000", bi.String())
+	n, err := a.Number(0)
+	require.NoError(t, err)
+	require.Equal(t, "1475508451862012000", n.String())
+}
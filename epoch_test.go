@@ -0,0 +1,65 @@
+package luna
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapTimeParsesRFC3339(t *testing.T) {
+	m := MapFromBytes([]byte(`{"created": "2016-10-03T15:27:29Z"}`))
+	ts, err := m.Time("created")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2016, 10, 3, 15, 27, 29, 0, time.UTC), ts.UTC())
+}
+
+func TestMapTimeParsesEpochSeconds(t *testing.T) {
+	m := MapFromBytes([]byte(`{"created": 0}`))
+	ts, err := m.Time("created")
+	require.NoError(t, err)
+	require.True(t, ts.Equal(time.Unix(0, 0)))
+}
+
+func TestMapTimeParsesEpochMicroseconds(t *testing.T) {
+	m := MapFromBytes([]byte(`{"created": 1475508449000000}`))
+	ts, err := m.Time("created")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2016, 10, 3, 15, 27, 29, 0, time.UTC), ts.UTC())
+}
+
+func TestMapMustTime(t *testing.T) {
+	m := MapFromBytes([]byte(`{"created": "2016-10-03T15:27:29Z"}`))
+	ts := m.MustTime("created")
+	require.Equal(t, time.Date(2016, 10, 3, 15, 27, 29, 0, time.UTC), ts.UTC())
+}
+
+func TestArrayMustTime(t *testing.T) {
+	a := ArrayFromBytes([]byte(`["2016-10-03T15:27:29Z"]`))
+	ts := a.MustTime(0)
+	require.Equal(t, time.Date(2016, 10, 3, 15, 27, 29, 0, time.UTC), ts.UTC())
+}
+
+func TestMapDurationParsesGoStyleString(t *testing.T) {
+	m := MapFromBytes([]byte(`{"timeout": "1h30m"}`))
+	d, err := m.Duration("timeout")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Minute, d)
+}
+
+func TestMapDurationParsesSeconds(t *testing.T) {
+	m := MapFromBytes([]byte(`{"timeout": 5}`))
+	d, err := m.Duration("timeout")
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, d)
+}
+
+func TestMapMustDuration(t *testing.T) {
+	m := MapFromBytes([]byte(`{"timeout": "1h30m"}`))
+	require.Equal(t, 90*time.Minute, m.MustDuration("timeout"))
+}
+
+func TestArrayMustDuration(t *testing.T) {
+	a := ArrayFromBytes([]byte(`[2.5]`))
+	require.Equal(t, 2500*time.Millisecond, a.MustDuration(0))
+}
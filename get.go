@@ -0,0 +1,231 @@
+package luna
+
+import (
+	"fmt"
+	stdpath "path"
+	"strconv"
+	"strings"
+)
+
+// gjsonStepKind identifies the kind of selector a single Get path segment applies.
+type gjsonStepKind int
+
+const (
+	gjsonChild  gjsonStepKind = iota // exact or glob ('*'/'?') key match
+	gjsonIndex                       // numeric array index
+	gjsonLength                      // a trailing '#': array length
+	gjsonMap                         // a '#' followed by more path: map the rest over each element
+)
+
+type gjsonStep struct {
+	kind  gjsonStepKind
+	name  string      // gjsonChild
+	index int         // gjsonIndex
+	rest  []gjsonStep // gjsonMap
+}
+
+// parseGetPath compiles a GJSON-style compact path (e.g. "people.0.score", "people.#",
+// "people.#.name", `fav\.movie`) into a sequence of steps. A dot separates segments; "\."
+// inside a segment is a literal dot rather than a separator.
+func parseGetPath(path string) ([]gjsonStep, error) {
+	return tokensToGetSteps(splitGetPath(path))
+}
+
+func splitGetPath(path string) []string {
+	var tokens []string
+	var cur strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '\\' && i+1 < len(path) && path[i+1] == '.' {
+			cur.WriteByte('.')
+			i++
+			continue
+		}
+		if c == '.' {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+			continue
+		}
+		cur.WriteByte(c)
+	}
+	tokens = append(tokens, cur.String())
+	return tokens
+}
+
+func tokensToGetSteps(tokens []string) ([]gjsonStep, error) {
+	var steps []gjsonStep
+	for i, tok := range tokens {
+		if tok == "#" {
+			if i == len(tokens)-1 {
+				steps = append(steps, gjsonStep{kind: gjsonLength})
+				return steps, nil
+			}
+			rest, err := tokensToGetSteps(tokens[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, gjsonStep{kind: gjsonMap, rest: rest})
+			return steps, nil
+		}
+		if idx, err := strconv.Atoi(tok); err == nil {
+			steps = append(steps, gjsonStep{kind: gjsonIndex, index: idx})
+			continue
+		}
+		steps = append(steps, gjsonStep{kind: gjsonChild, name: tok})
+	}
+	return steps, nil
+}
+
+func isGlobKey(name string) bool {
+	return strings.ContainsAny(name, "*?")
+}
+
+func evalGetSteps(steps []gjsonStep, nodes []queryNode) ([]queryNode, error) {
+	cur := nodes
+	for _, st := range steps {
+		next, err := applyGetStep(st, cur)
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func applyGetStep(st gjsonStep, cur []queryNode) ([]queryNode, error) {
+	var out []queryNode
+	switch st.kind {
+	case gjsonChild:
+		for _, n := range cur {
+			m, ok := n.value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("item at path %s was a %T, not a map", n.path, n.value)
+			}
+			if isGlobKey(st.name) {
+				for _, k := range sortedKeys(m) {
+					if ok, _ := stdpath.Match(st.name, k); ok {
+						out = append(out, queryNode{m[k], n.path.appendKey(k)})
+					}
+				}
+				continue
+			}
+			v, exists := m[st.name]
+			if !exists {
+				return nil, missingKeyErr(n.path, st.name, m)
+			}
+			out = append(out, queryNode{v, n.path.appendKey(st.name)})
+		}
+	case gjsonIndex:
+		for _, n := range cur {
+			a, ok := n.value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("item at path %s was a %T, not an array", n.path, n.value)
+			}
+			idx := st.index
+			if idx < 0 {
+				idx += len(a)
+			}
+			if idx < 0 || idx >= len(a) {
+				return nil, fmt.Errorf("index %d out of range for array of length %d at path %s", st.index, len(a), n.path)
+			}
+			out = append(out, queryNode{a[idx], n.path.appendIndex(idx)})
+		}
+	case gjsonLength:
+		for _, n := range cur {
+			a, ok := n.value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("item at path %s was a %T, not an array", n.path, n.value)
+			}
+			out = append(out, queryNode{float64(len(a)), n.path})
+		}
+	case gjsonMap:
+		for _, n := range cur {
+			a, ok := n.value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("item at path %s was a %T, not an array", n.path, n.value)
+			}
+			for i, item := range a {
+				itemNodes, err := evalGetSteps(st.rest, []queryNode{{item, n.path.appendIndex(i)}})
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, itemNodes...)
+			}
+		}
+	}
+	return out, nil
+}
+
+func missingKeyErr(p path, key string, m map[string]interface{}) error {
+	validKeys := make([]string, 0, len(m))
+	for k := range m {
+		validKeys = append(validKeys, k)
+	}
+	return fmt.Errorf("key '%s' not found at path %s, valid keys: %+v", key, p, strings.Join(validKeys, ", "))
+}
+
+func runGet(path string, root queryNode) Result {
+	steps, err := parseGetPath(path)
+	if err != nil {
+		return Result{err: err}
+	}
+	nodes, err := evalGetSteps(steps, []queryNode{root})
+	if err != nil {
+		return Result{err: err}
+	}
+	return Result{nodes: nodes}
+}
+
+// Get evaluates a compact GJSON-style path (e.g. "people.0.score", "people.#", "people.#.name")
+// against the map and returns the matching node-set, or a propagated error. Unlike Query, Get
+// supports a trailing "#" for array length, "#.field" to map a field over every element, and
+// "*"/"?" globs inside a key name.
+func (m Map) Get(path string) Result {
+	if m.err != nil {
+		return Result{err: m.err}
+	}
+	return runGet(path, queryNode{m.m, m.path})
+}
+
+// MustGet evaluates a Get path, or panics if there was an error.
+func (m Map) MustGet(path string) Result {
+	r := m.Get(path)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+// Get evaluates a compact GJSON-style path against the array and returns the matching
+// node-set, or a propagated error. See Map.Get for the supported path grammar.
+func (a Array) Get(path string) Result {
+	if a.err != nil {
+		return Result{err: a.err}
+	}
+	return runGet(path, queryNode{a.a, a.path})
+}
+
+// MustGet evaluates a Get path, or panics if there was an error.
+func (a Array) MustGet(path string) Result {
+	r := a.Get(path)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+// Get re-evaluates a GJSON-style path rooted at each node already matched by this Result.
+func (r Result) Get(path string) Result {
+	if r.err != nil {
+		return r
+	}
+	steps, err := parseGetPath(path)
+	if err != nil {
+		return Result{err: err}
+	}
+	nodes, err := evalGetSteps(steps, r.nodes)
+	if err != nil {
+		return Result{err: err}
+	}
+	return Result{nodes: nodes}
+}
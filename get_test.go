@@ -0,0 +1,68 @@
+package luna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var getData = []byte(`{
+	"people": [
+		{"name": "alice", "score": 89.5},
+		{"name": "bob", "score": 75.5}
+	],
+	"fav.movie": "Inception"
+}`)
+
+func TestGetIndexedField(t *testing.T) {
+	m := MapFromBytes(getData)
+	score, err := m.Get("people.0.score").AsFloat()
+	require.NoError(t, err)
+	require.Equal(t, 89.5, score)
+}
+
+func TestGetArrayLength(t *testing.T) {
+	m := MapFromBytes(getData)
+	n, err := m.Get("people.#").AsInt()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestGetMappedField(t *testing.T) {
+	m := MapFromBytes(getData)
+	names, err := m.Get("people.#.name").AsArray()
+	require.NoError(t, err)
+	require.Equal(t, 2, names.MustLen())
+	n0, err := names.String(0)
+	require.NoError(t, err)
+	require.Equal(t, "alice", n0)
+}
+
+func TestGetWildcardKey(t *testing.T) {
+	m := MapFromBytes([]byte(`{"user_1": "a", "user_2": "b", "admin": "c"}`))
+	vals, err := m.Get("user_*").AsArray()
+	require.NoError(t, err)
+	require.Equal(t, 2, vals.MustLen())
+}
+
+func TestGetEscapedDot(t *testing.T) {
+	m := MapFromBytes(getData)
+	v, err := m.Get(`fav\.movie`).AsString()
+	require.NoError(t, err)
+	require.Equal(t, "Inception", v)
+}
+
+func TestGetMissingKeyListsSiblings(t *testing.T) {
+	m := MapFromBytes(getData)
+	_, err := m.Get("peeple").AsString()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "peeple")
+	require.Contains(t, err.Error(), "people")
+}
+
+func TestMustGetPanics(t *testing.T) {
+	m := MapFromBytes(getData)
+	require.Panics(t, func() {
+		m.MustGet("missing")
+	})
+}
@@ -0,0 +1,1079 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backend parses raw JSON into a Map or Array. It is the pluggable entry point behind
+// MapFromBytesWithBackend/ArrayFromBytesWithBackend: DecoderBackend wraps the existing
+// Decoder-based machinery, which decodes the whole payload into
+// map[string]interface{}/[]interface{} up front, while FastBackend lazily indexes offsets in
+// the source buffer and only materializes a Go value when a leaf accessor is called.
+type Backend interface {
+	ParseMap(data []byte) Map
+	ParseArray(data []byte) Array
+}
+
+// DecoderBackend adapts a Decoder to the Backend interface, so the default eager,
+// encoding/json-based parsing (and NumberDecoder) can be reached through the same pluggable
+// entry point as FastBackend.
+func DecoderBackend(d Decoder) Backend {
+	return decoderBackend{d}
+}
+
+type decoderBackend struct {
+	d Decoder
+}
+
+func (b decoderBackend) ParseMap(data []byte) Map {
+	return MapFromBytesWith(data, b.d)
+}
+
+func (b decoderBackend) ParseArray(data []byte) Array {
+	return ArrayFromBytesWith(data, b.d)
+}
+
+// MapFromBytesWithBackend creates a Map from a []byte using the given Backend.
+func MapFromBytesWithBackend(data []byte, b Backend) Map {
+	return b.ParseMap(data)
+}
+
+// ArrayFromBytesWithBackend creates an Array from a []byte using the given Backend.
+func ArrayFromBytesWithBackend(data []byte, b Backend) Array {
+	return b.ParseArray(data)
+}
+
+// FastBackend returns a Backend, in the spirit of buger/jsonparser, that never decodes the
+// source buffer into map[string]interface{}/[]interface{}. Instead it indexes the byte offsets
+// of each top-level key/element as they're visited, so a chained traversal like
+// Map(...).Array(...).Map(...) walks the raw bytes without allocating any intermediate
+// container. A leaf accessor (String/Float/Int64/Uint64/...) still materializes just the Go
+// value it needs from its own byte span; Inner/MustInner and Query/MustQuery, which need a real Go
+// value shaped like map[string]interface{}, fall back to decoding their span in full.
+func FastBackend() Backend {
+	return fastBackend{}
+}
+
+type fastBackend struct{}
+
+func (fastBackend) ParseMap(data []byte) Map {
+	sp, err := topLevelSpan(data, '{', "a map")
+	if err != nil {
+		return errorMap{err}
+	}
+	return fastMap{data, sp}
+}
+
+func (fastBackend) ParseArray(data []byte) Array {
+	sp, err := topLevelSpan(data, '[', "an array")
+	if err != nil {
+		return errorArray{err}
+	}
+	return fastArray{data, sp}
+}
+
+// span is a half-open [start, end) byte range in a fastMap/fastArray's shared source buffer,
+// covering exactly one JSON value, including its surrounding quotes/braces/brackets.
+type span struct {
+	start, end int
+}
+
+func topLevelSpan(data []byte, open byte, wantKind string) (span, error) {
+	pos := skipWS(data, 0)
+	if pos >= len(data) || data[pos] != open {
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return span{}, err
+		}
+		return span{}, fmt.Errorf("decoded value was a %T, not %s", v, wantKind)
+	}
+	end, err := scanContainer(data, pos)
+	if err != nil {
+		return span{}, err
+	}
+	return span{pos, end}, nil
+}
+
+func isWS(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func skipWS(data []byte, pos int) int {
+	for pos < len(data) && isWS(data[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// scanString returns the offset just past the closing quote of the string starting at pos.
+func scanString(data []byte, pos int) (int, error) {
+	i := pos + 1
+	for i < len(data) {
+		switch data[i] {
+		case '\\':
+			i += 2
+		case '"':
+			return i + 1, nil
+		default:
+			i++
+		}
+	}
+	return 0, fmt.Errorf("unterminated string starting at offset %d", pos)
+}
+
+// scanContainer returns the offset just past the closing brace/bracket matching the one at
+// pos, tracking nesting depth and skipping over the contents of string literals.
+func scanContainer(data []byte, pos int) (int, error) {
+	open, close := data[pos], closerFor(data[pos])
+	depth := 0
+	inString := false
+	for i := pos; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unterminated container starting at offset %d", pos)
+}
+
+func closerFor(open byte) byte {
+	if open == '{' {
+		return '}'
+	}
+	return ']'
+}
+
+// scanValue returns the span of the JSON value starting at or after pos, and the offset just
+// past it (before any trailing whitespace/comma/closing delimiter).
+func scanValue(data []byte, pos int) (span, int, error) {
+	pos = skipWS(data, pos)
+	if pos >= len(data) {
+		return span{}, 0, fmt.Errorf("unexpected end of JSON at offset %d", pos)
+	}
+	switch data[pos] {
+	case '"':
+		end, err := scanString(data, pos)
+		return span{pos, end}, end, err
+	case '{', '[':
+		end, err := scanContainer(data, pos)
+		return span{pos, end}, end, err
+	default:
+		i := pos
+		for i < len(data) && data[i] != ',' && data[i] != '}' && data[i] != ']' && !isWS(data[i]) {
+			i++
+		}
+		if i == pos {
+			return span{}, 0, fmt.Errorf("unexpected character %q at offset %d", data[pos], pos)
+		}
+		return span{pos, i}, i, nil
+	}
+}
+
+// iterateObject visits each key/value pair of the object spanning objSpan in declaration
+// order, stopping early if fn returns false. rawKey is the key's raw, still-quoted-escaped bytes.
+func iterateObject(data []byte, objSpan span, fn func(rawKey []byte, valSpan span) bool) error {
+	pos := objSpan.start + 1
+	for {
+		pos = skipWS(data, pos)
+		if pos >= objSpan.end-1 || data[pos] == '}' {
+			return nil
+		}
+		if data[pos] != '"' {
+			return fmt.Errorf("malformed object: expected a key at offset %d", pos)
+		}
+		keyEnd, err := scanString(data, pos)
+		if err != nil {
+			return err
+		}
+		rawKey := data[pos+1 : keyEnd-1]
+		colon := skipWS(data, keyEnd)
+		if colon >= len(data) || data[colon] != ':' {
+			return fmt.Errorf("malformed object: expected ':' at offset %d", colon)
+		}
+		valSpan, next, err := scanValue(data, colon+1)
+		if err != nil {
+			return err
+		}
+		if !fn(rawKey, valSpan) {
+			return nil
+		}
+		pos = skipWS(data, next)
+		if pos < len(data) && data[pos] == ',' {
+			pos++
+			continue
+		}
+		return nil
+	}
+}
+
+// iterateArray visits each element of the array spanning arrSpan in order, stopping early if
+// fn returns false.
+func iterateArray(data []byte, arrSpan span, fn func(idx int, valSpan span) bool) error {
+	pos := arrSpan.start + 1
+	idx := 0
+	for {
+		pos = skipWS(data, pos)
+		if pos >= arrSpan.end-1 || data[pos] == ']' {
+			return nil
+		}
+		valSpan, next, err := scanValue(data, pos)
+		if err != nil {
+			return err
+		}
+		if !fn(idx, valSpan) {
+			return nil
+		}
+		idx++
+		pos = skipWS(data, next)
+		if pos < len(data) && data[pos] == ',' {
+			pos++
+			continue
+		}
+		return nil
+	}
+}
+
+func unescapeKey(rawKey []byte) string {
+	for _, c := range rawKey {
+		if c == '\\' {
+			var s string
+			if err := json.Unmarshal(append([]byte{'"'}, append(append([]byte{}, rawKey...), '"')...), &s); err == nil {
+				return s
+			}
+			break
+		}
+	}
+	return string(rawKey)
+}
+
+func keyMatches(rawKey []byte, key string) bool {
+	for _, c := range rawKey {
+		if c == '\\' {
+			return unescapeKey(rawKey) == key
+		}
+	}
+	return string(rawKey) == key
+}
+
+func isNumberSpan(data []byte, sp span) bool {
+	if sp.start >= len(data) {
+		return false
+	}
+	c := data[sp.start]
+	return c == '-' || (c >= '0' && c <= '9')
+}
+
+// kindName names the Go type a span would materialize to, matching the %T text the
+// Decoder-based backend already produces for the same JSON value, so error messages are
+// identical across backends.
+func kindName(data []byte, sp span) string {
+	if sp.start >= len(data) {
+		return "<nil>"
+	}
+	switch data[sp.start] {
+	case '"':
+		return "string"
+	case '{':
+		return "map[string]interface {}"
+	case '[':
+		return "[]interface {}"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "<nil>"
+	default:
+		return "float64"
+	}
+}
+
+func spanAsString(data []byte, sp span) (string, bool) {
+	if sp.start >= len(data) || data[sp.start] != '"' {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(data[sp.start:sp.end], &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+func spanAsFloat(data []byte, sp span) (float64, bool) {
+	if !isNumberSpan(data, sp) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(string(data[sp.start:sp.end]), 64)
+	return f, err == nil
+}
+
+func spanAsNumber(data []byte, sp span) (json.Number, bool) {
+	if !isNumberSpan(data, sp) {
+		return "", false
+	}
+	return json.Number(data[sp.start:sp.end]), true
+}
+
+func spanAsBigInt(data []byte, sp span) (*big.Int, bool) {
+	if !isNumberSpan(data, sp) {
+		return nil, false
+	}
+	text := string(data[sp.start:sp.end])
+	if bi, ok := new(big.Int).SetString(text, 10); ok {
+		return bi, true
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, false
+	}
+	bi, _ := big.NewFloat(f).Int(nil)
+	return bi, true
+}
+
+// spanAsScalar returns the span's value as a json.Number (for a numeric span) or a string
+// (for a string span), the two representations toInt64/toUint64/toTime/toDuration accept in
+// place of a float64, so those conversions work the same way over FastBackend's lazily
+// materialized spans as they do over an already-decoded Go value.
+func spanAsScalar(data []byte, sp span) (interface{}, bool) {
+	if n, ok := spanAsNumber(data, sp); ok {
+		return n, true
+	}
+	if s, ok := spanAsString(data, sp); ok {
+		return s, true
+	}
+	return nil, false
+}
+
+func spanAsBool(data []byte, sp span) (bool, bool) {
+	text := string(data[sp.start:sp.end])
+	switch text {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	}
+	return false, false
+}
+
+func spanBytes(data []byte, sp span) []byte {
+	return append([]byte(nil), data[sp.start:sp.end]...)
+}
+
+func spanAsGoValue(data []byte, sp span) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(data[sp.start:sp.end], &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type fastMap struct {
+	data []byte
+	span span
+}
+
+func (fm fastMap) findKey(key string) (span, bool, error) {
+	var found span
+	ok := false
+	err := iterateObject(fm.data, fm.span, func(rawKey []byte, valSpan span) bool {
+		if keyMatches(rawKey, key) {
+			found, ok = valSpan, true
+			return false
+		}
+		return true
+	})
+	return found, ok, err
+}
+
+func (fm fastMap) keys() ([]string, error) {
+	var keys []string
+	err := iterateObject(fm.data, fm.span, func(rawKey []byte, _ span) bool {
+		keys = append(keys, unescapeKey(rawKey))
+		return true
+	})
+	return keys, err
+}
+
+func (fm fastMap) missingKeyErr(key string) error {
+	keys, err := fm.keys()
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("key not found: %s, valid keys: %+v", key, strings.Join(keys, ", "))
+}
+
+func (fm fastMap) Has(key string) (bool, error) {
+	_, found, err := fm.findKey(key)
+	return found, err
+}
+
+func (fm fastMap) MustHas(key string) bool {
+	found, err := fm.Has(key)
+	if err != nil {
+		panic(err)
+	}
+	return found
+}
+
+func (fm fastMap) String(key string) (string, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fm.missingKeyErr(key)
+	}
+	s, ok := spanAsString(fm.data, valSpan)
+	if !ok {
+		return "", fmt.Errorf("item with key %s was a %s, not a string", key, kindName(fm.data, valSpan))
+	}
+	return s, nil
+}
+
+func (fm fastMap) Float(key string) (float64, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fm.missingKeyErr(key)
+	}
+	f, ok := spanAsFloat(fm.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item with key %s was a %s, not a float", key, kindName(fm.data, valSpan))
+	}
+	return f, nil
+}
+
+func (fm fastMap) Int(key string) (int, error) {
+	f, err := fm.Float(key)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+func (fm fastMap) BigInt(key string) (*big.Int, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fm.missingKeyErr(key)
+	}
+	bi, ok := spanAsBigInt(fm.data, valSpan)
+	if !ok {
+		return nil, fmt.Errorf("item with key %s was a %s, not an integer", key, kindName(fm.data, valSpan))
+	}
+	return bi, nil
+}
+
+func (fm fastMap) Number(key string) (json.Number, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fm.missingKeyErr(key)
+	}
+	n, ok := spanAsNumber(fm.data, valSpan)
+	if !ok {
+		return "", fmt.Errorf("item with key %s was a %s, not a number", key, kindName(fm.data, valSpan))
+	}
+	return n, nil
+}
+
+func (fm fastMap) Int64(key string) (int64, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fm.missingKeyErr(key)
+	}
+	scalar, ok := spanAsScalar(fm.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item with key %s was a %s, not a number", key, kindName(fm.data, valSpan))
+	}
+	i, err := toInt64(scalar)
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return i, nil
+}
+
+func (fm fastMap) Uint64(key string) (uint64, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fm.missingKeyErr(key)
+	}
+	scalar, ok := spanAsScalar(fm.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item with key %s was a %s, not a number", key, kindName(fm.data, valSpan))
+	}
+	u, err := toUint64(scalar)
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return u, nil
+}
+
+func (fm fastMap) Time(key string) (time.Time, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !found {
+		return time.Time{}, fm.missingKeyErr(key)
+	}
+	scalar, ok := spanAsScalar(fm.data, valSpan)
+	if !ok {
+		return time.Time{}, fmt.Errorf("item with key %s was a %s, not a timestamp", key, kindName(fm.data, valSpan))
+	}
+	t, err := toTime(scalar)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return t, nil
+}
+
+func (fm fastMap) Duration(key string) (time.Duration, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fm.missingKeyErr(key)
+	}
+	scalar, ok := spanAsScalar(fm.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item with key %s was a %s, not a duration", key, kindName(fm.data, valSpan))
+	}
+	d, err := toDuration(scalar)
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return d, nil
+}
+
+func (fm fastMap) Bool(key string) (bool, error) {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, fm.missingKeyErr(key)
+	}
+	b, ok := spanAsBool(fm.data, valSpan)
+	if !ok {
+		return false, fmt.Errorf("item with key %s was a %s, not a bool", key, kindName(fm.data, valSpan))
+	}
+	return b, nil
+}
+
+func (fm fastMap) Map(key string) Map {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return errorMap{err}
+	}
+	if !found {
+		return errorMap{fm.missingKeyErr(key)}
+	}
+	if valSpan.start >= len(fm.data) || fm.data[valSpan.start] != '{' {
+		return errorMap{fmt.Errorf("item with key %s was a %s, not a map", key, kindName(fm.data, valSpan))}
+	}
+	return fastMap{fm.data, valSpan}
+}
+
+func (fm fastMap) Array(key string) Array {
+	valSpan, found, err := fm.findKey(key)
+	if err != nil {
+		return errorArray{err}
+	}
+	if !found {
+		return errorArray{fm.missingKeyErr(key)}
+	}
+	if valSpan.start >= len(fm.data) || fm.data[valSpan.start] != '[' {
+		return errorArray{fmt.Errorf("item with key %s was a %s, not an array", key, kindName(fm.data, valSpan))}
+	}
+	return fastArray{fm.data, valSpan}
+}
+
+func (fm fastMap) Bytes() ([]byte, error) {
+	return spanBytes(fm.data, fm.span), nil
+}
+
+func (fm fastMap) MustBytes() []byte {
+	b, _ := fm.Bytes()
+	return b
+}
+
+func (fm fastMap) Inner() (map[string]interface{}, error) {
+	v, err := spanAsGoValue(fm.data, fm.span)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("decoded value was a %T, not a map", v)
+	}
+	return m, nil
+}
+
+func (fm fastMap) MustInner() map[string]interface{} {
+	m, err := fm.Inner()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (fm fastMap) MustString(key string) string {
+	s, err := fm.String(key)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (fm fastMap) MustFloat(key string) float64 {
+	f, err := fm.Float(key)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (fm fastMap) MustInt(key string) int {
+	i, err := fm.Int(key)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (fm fastMap) MustBigInt(key string) *big.Int {
+	bi, err := fm.BigInt(key)
+	if err != nil {
+		panic(err)
+	}
+	return bi
+}
+
+func (fm fastMap) MustNumber(key string) json.Number {
+	n, err := fm.Number(key)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (fm fastMap) MustInt64(key string) int64 {
+	i, err := fm.Int64(key)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (fm fastMap) MustUint64(key string) uint64 {
+	u, err := fm.Uint64(key)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func (fm fastMap) MustTime(key string) time.Time {
+	t, err := fm.Time(key)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (fm fastMap) MustDuration(key string) time.Duration {
+	d, err := fm.Duration(key)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (fm fastMap) MustBool(key string) bool {
+	b, err := fm.Bool(key)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (fm fastMap) Err() error {
+	return nil
+}
+
+func (fm fastMap) Query(expr string) Result {
+	v, err := fm.Inner()
+	if err != nil {
+		return Result{err: err}
+	}
+	return runQuery(expr, queryNode{v, "$"})
+}
+
+func (fm fastMap) MustQuery(expr string) Result {
+	r := fm.Query(expr)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+type fastArray struct {
+	data []byte
+	span span
+}
+
+// valueAt returns the span of the element at idx, and the array's total length (always
+// accurate, even on a miss, since a miss means iteration ran to completion).
+func (fa fastArray) valueAt(idx int) (span, int, error) {
+	var found span
+	ok := false
+	length := 0
+	err := iterateArray(fa.data, fa.span, func(i int, valSpan span) bool {
+		length = i + 1
+		if i == idx {
+			found, ok = valSpan, true
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return span{}, 0, err
+	}
+	if !ok {
+		return span{}, length, fmt.Errorf("invalid index: %d; it should be between 0 and %d", idx, length-1)
+	}
+	return found, length, nil
+}
+
+func (fa fastArray) Len() (int, error) {
+	length := 0
+	err := iterateArray(fa.data, fa.span, func(i int, _ span) bool {
+		length = i + 1
+		return true
+	})
+	return length, err
+}
+
+func (fa fastArray) MustLen() int {
+	l, err := fa.Len()
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func (fa fastArray) String(idx int) (string, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return "", err
+	}
+	s, ok := spanAsString(fa.data, valSpan)
+	if !ok {
+		return "", fmt.Errorf("item at index %d was a %s, not a string", idx, kindName(fa.data, valSpan))
+	}
+	return s, nil
+}
+
+func (fa fastArray) Float(idx int) (float64, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := spanAsFloat(fa.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item at index %d was a %s, not a float", idx, kindName(fa.data, valSpan))
+	}
+	return f, nil
+}
+
+func (fa fastArray) Int(idx int) (int, error) {
+	f, err := fa.Float(idx)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+func (fa fastArray) BigInt(idx int) (*big.Int, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return nil, err
+	}
+	bi, ok := spanAsBigInt(fa.data, valSpan)
+	if !ok {
+		return nil, fmt.Errorf("item at index %d was a %s, not an integer", idx, kindName(fa.data, valSpan))
+	}
+	return bi, nil
+}
+
+func (fa fastArray) Number(idx int) (json.Number, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return "", err
+	}
+	n, ok := spanAsNumber(fa.data, valSpan)
+	if !ok {
+		return "", fmt.Errorf("item at index %d was a %s, not a number", idx, kindName(fa.data, valSpan))
+	}
+	return n, nil
+}
+
+func (fa fastArray) Int64(idx int) (int64, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return 0, err
+	}
+	scalar, ok := spanAsScalar(fa.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item at index %d was a %s, not a number", idx, kindName(fa.data, valSpan))
+	}
+	i, err := toInt64(scalar)
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return i, nil
+}
+
+func (fa fastArray) Uint64(idx int) (uint64, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return 0, err
+	}
+	scalar, ok := spanAsScalar(fa.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item at index %d was a %s, not a number", idx, kindName(fa.data, valSpan))
+	}
+	u, err := toUint64(scalar)
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return u, nil
+}
+
+func (fa fastArray) Time(idx int) (time.Time, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	scalar, ok := spanAsScalar(fa.data, valSpan)
+	if !ok {
+		return time.Time{}, fmt.Errorf("item at index %d was a %s, not a timestamp", idx, kindName(fa.data, valSpan))
+	}
+	t, err := toTime(scalar)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return t, nil
+}
+
+func (fa fastArray) Duration(idx int) (time.Duration, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return 0, err
+	}
+	scalar, ok := spanAsScalar(fa.data, valSpan)
+	if !ok {
+		return 0, fmt.Errorf("item at index %d was a %s, not a duration", idx, kindName(fa.data, valSpan))
+	}
+	d, err := toDuration(scalar)
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return d, nil
+}
+
+func (fa fastArray) Bool(idx int) (bool, error) {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return false, err
+	}
+	b, ok := spanAsBool(fa.data, valSpan)
+	if !ok {
+		return false, fmt.Errorf("item at index %d was a %s, not a bool", idx, kindName(fa.data, valSpan))
+	}
+	return b, nil
+}
+
+func (fa fastArray) Map(idx int) Map {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return errorMap{err}
+	}
+	if valSpan.start >= len(fa.data) || fa.data[valSpan.start] != '{' {
+		return errorMap{fmt.Errorf("item at index %d was a %s, not a map", idx, kindName(fa.data, valSpan))}
+	}
+	return fastMap{fa.data, valSpan}
+}
+
+func (fa fastArray) Array(idx int) Array {
+	valSpan, _, err := fa.valueAt(idx)
+	if err != nil {
+		return errorArray{err}
+	}
+	if valSpan.start >= len(fa.data) || fa.data[valSpan.start] != '[' {
+		return errorArray{fmt.Errorf("item at index %d was a %s, not an array", idx, kindName(fa.data, valSpan))}
+	}
+	return fastArray{fa.data, valSpan}
+}
+
+func (fa fastArray) Inner() ([]interface{}, error) {
+	v, err := spanAsGoValue(fa.data, fa.span)
+	if err != nil {
+		return nil, err
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("decoded value was a %T, not an array", v)
+	}
+	return a, nil
+}
+
+func (fa fastArray) MustInner() []interface{} {
+	a, err := fa.Inner()
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (fa fastArray) Bytes() ([]byte, error) {
+	return spanBytes(fa.data, fa.span), nil
+}
+
+func (fa fastArray) MustBytes() []byte {
+	b, _ := fa.Bytes()
+	return b
+}
+
+func (fa fastArray) MustString(idx int) string {
+	s, err := fa.String(idx)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (fa fastArray) MustFloat(idx int) float64 {
+	f, err := fa.Float(idx)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (fa fastArray) MustInt(idx int) int {
+	i, err := fa.Int(idx)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (fa fastArray) MustBigInt(idx int) *big.Int {
+	bi, err := fa.BigInt(idx)
+	if err != nil {
+		panic(err)
+	}
+	return bi
+}
+
+func (fa fastArray) MustNumber(idx int) json.Number {
+	n, err := fa.Number(idx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (fa fastArray) MustBool(idx int) bool {
+	b, err := fa.Bool(idx)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (fa fastArray) MustInt64(idx int) int64 {
+	i, err := fa.Int64(idx)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (fa fastArray) MustUint64(idx int) uint64 {
+	u, err := fa.Uint64(idx)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func (fa fastArray) MustTime(idx int) time.Time {
+	t, err := fa.Time(idx)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (fa fastArray) MustDuration(idx int) time.Duration {
+	d, err := fa.Duration(idx)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (fa fastArray) Err() error {
+	return nil
+}
+
+func (fa fastArray) Query(expr string) Result {
+	v, err := fa.Inner()
+	if err != nil {
+		return Result{err: err}
+	}
+	return runQuery(expr, queryNode{v, "$"})
+}
+
+func (fa fastArray) MustQuery(expr string) Result {
+	r := fa.Query(expr)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
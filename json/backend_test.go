@@ -0,0 +1,203 @@
+package json
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// backends is the shared conformance table: every case below runs once per backend, so a
+// regression that only affects one implementation (e.g. FastBackend's lazy tape) shows up
+// immediately as a diff against the Decoder-based baseline.
+var backends = []struct {
+	name    string
+	backend Backend
+}{
+	{"decoder", DecoderBackend(defaultDecoder)},
+	{"fast", FastBackend()},
+}
+
+func forEachBackend(t *testing.T, fn func(t *testing.T, b Backend)) {
+	for _, b := range backends {
+		b := b
+		t.Run(b.name, func(t *testing.T) {
+			fn(t, b.backend)
+		})
+	}
+}
+
+var backendConformanceData = []byte(`{
+	"people": [
+		{"name": "alice", "score": 89.5, "deleted": false, "friends": ["bob"]},
+		{"name": "bob", "score": 75.5, "deleted": false, "friends": []}
+	],
+	"count": 2,
+	"fav\"quote": "hi"
+}`)
+
+func TestBackendConformanceNestedAccess(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		score, err := m.Array("people").Map(0).Float("score")
+		require.NoError(t, err)
+		require.Equal(t, 89.5, score)
+
+		name, err := m.Array("people").Map(1).String("name")
+		require.NoError(t, err)
+		require.Equal(t, "bob", name)
+
+		deleted, err := m.Array("people").Map(0).Bool("deleted")
+		require.NoError(t, err)
+		require.False(t, deleted)
+
+		friend, err := m.Array("people").Map(0).Array("friends").String(0)
+		require.NoError(t, err)
+		require.Equal(t, "bob", friend)
+	})
+}
+
+func TestBackendConformanceCount(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		count, err := m.Int("count")
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+}
+
+func TestBackendConformanceEscapedKey(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		v, err := m.String(`fav"quote`)
+		require.NoError(t, err)
+		require.Equal(t, "hi", v)
+	})
+}
+
+func TestBackendConformanceMissingKeyListsSiblings(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		_, err := m.Array("entries").Map(0).Float("score")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "entries")
+		require.Contains(t, err.Error(), "people")
+		require.Contains(t, err.Error(), "count")
+	})
+}
+
+func TestBackendConformanceArrayOutOfRange(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		_, err := m.Array("people").Map(5).Float("score")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid index: 5")
+	})
+}
+
+func TestBackendConformanceWrongTypeError(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		_, err := m.String("count")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a string")
+
+		arrErr := m.Array("count").Err()
+		require.Error(t, arrErr)
+		require.Contains(t, arrErr.Error(), "not an array")
+	})
+}
+
+func TestBackendConformanceLen(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		l, err := m.Array("people").Len()
+		require.NoError(t, err)
+		require.Equal(t, 2, l)
+	})
+}
+
+func TestBackendConformanceInner(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		inner, err := m.Inner()
+		require.NoError(t, err)
+		require.Equal(t, 2.0, inner["count"])
+	})
+}
+
+func TestBackendConformanceQuery(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(backendConformanceData, b)
+		names, err := m.Query("$.people[*].name").AsArray()
+		require.NoError(t, err)
+		require.Equal(t, 2, names.MustLen())
+	})
+}
+
+func TestBackendConformanceInt64Uint64TimeDuration(t *testing.T) {
+	data := []byte(`{
+		"id": "9223372036854775000",
+		"count": 5,
+		"created": "2016-10-03T15:27:29Z",
+		"timeout": "1h30m",
+		"ids": ["42", 7],
+		"timestamps": ["2016-10-03T15:27:29Z"],
+		"timeouts": [5]
+	}`)
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend(data, b)
+
+		id, err := m.Int64("id")
+		require.NoError(t, err)
+		require.Equal(t, int64(9223372036854775000), id)
+
+		count, err := m.Uint64("count")
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), count)
+
+		created, err := m.Time("created")
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2016, 10, 3, 15, 27, 29, 0, time.UTC), created.UTC())
+
+		timeout, err := m.Duration("timeout")
+		require.NoError(t, err)
+		require.Equal(t, 90*time.Minute, timeout)
+
+		a := m.Array("ids")
+		i, err := a.Int64(0)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), i)
+		require.Equal(t, uint64(7), a.MustUint64(1))
+
+		require.Equal(t, created.UTC(), m.Array("timestamps").MustTime(0).UTC())
+		require.Equal(t, 5*time.Second, m.Array("timeouts").MustDuration(0))
+	})
+}
+
+func TestBackendConformanceTopLevelArray(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		a := ArrayFromBytesWithBackend([]byte(`[1, 2, 3]`), b)
+		l, err := a.Len()
+		require.NoError(t, err)
+		require.Equal(t, 3, l)
+		i, err := a.Int(1)
+		require.NoError(t, err)
+		require.Equal(t, 2, i)
+	})
+}
+
+func TestBackendConformanceTopLevelTypeMismatch(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b Backend) {
+		m := MapFromBytesWithBackend([]byte(`[1, 2, 3]`), b)
+		require.Error(t, m.Err())
+		require.Contains(t, m.Err().Error(), "not a map")
+	})
+}
+
+func TestFastBackendBytesReturnsOriginalSpan(t *testing.T) {
+	m := MapFromBytesWithBackend(backendConformanceData, FastBackend())
+	b, err := m.Array("people").Map(0).Bytes()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name": "alice", "score": 89.5, "deleted": false, "friends": ["bob"]}`, string(b))
+}
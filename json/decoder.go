@@ -0,0 +1,111 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+)
+
+// Decoder turns raw JSON into a Go value. It is the extension point `MapFromBytesWith` /
+// `ArrayFromBytesWith` use instead of hard-coding `encoding/json`, so callers can swap in a
+// faster or more precise backend (e.g. jsoniter, go-json) without forking the package.
+type Decoder interface {
+	// Unmarshal decodes data into v, which will hold a map[string]interface{} or []interface{}.
+	Unmarshal(data []byte, v *interface{}) error
+	// NewStreamDecoder returns a StreamDecoder reading successive values from r.
+	NewStreamDecoder(r io.Reader) StreamDecoder
+}
+
+// StreamDecoder decodes successive JSON values from a stream, mirroring *json.Decoder.
+type StreamDecoder interface {
+	Decode(v *interface{}) error
+}
+
+var defaultDecoder Decoder = stdDecoder{}
+
+// SetDefaultDecoder changes the Decoder used by MapFromBytes, MapFromReader, ArrayFromBytes,
+// and ArrayFromReader for the remainder of the program.
+func SetDefaultDecoder(d Decoder) {
+	defaultDecoder = d
+}
+
+// stdDecoder is the default Decoder, backed directly by encoding/json; JSON numbers decode
+// to float64, which loses precision above 2^53.
+type stdDecoder struct{}
+
+func (stdDecoder) Unmarshal(data []byte, v *interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (stdDecoder) NewStreamDecoder(r io.Reader) StreamDecoder {
+	return stdStreamDecoder{json.NewDecoder(r)}
+}
+
+type stdStreamDecoder struct {
+	d *json.Decoder
+}
+
+func (s stdStreamDecoder) Decode(v *interface{}) error {
+	return s.d.Decode(v)
+}
+
+// numberDecoder is a Decoder that decodes JSON numbers as json.Number instead of float64, so
+// 64-bit ids (Twitter/Discord/Snowflake style) survive a round trip without losing precision.
+type numberDecoder struct{}
+
+// NumberDecoder returns a Decoder that decodes JSON numbers as json.Number instead of
+// float64. Pair it with BigInt, Number, Int64, or Uint64 to read large ids losslessly.
+func NumberDecoder() Decoder {
+	return numberDecoder{}
+}
+
+func (numberDecoder) Unmarshal(data []byte, v *interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func (numberDecoder) NewStreamDecoder(r io.Reader) StreamDecoder {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	return stdStreamDecoder{d}
+}
+
+// toFloat accepts either a float64 (the default decoder) or a json.Number (NumberDecoder).
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toNumber accepts either a float64 or a json.Number and normalizes it to a json.Number.
+func toNumber(v interface{}) (json.Number, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		return n, true
+	case float64:
+		return json.Number(strconv.FormatFloat(n, 'f', -1, 64)), true
+	}
+	return "", false
+}
+
+// toBigInt accepts either a float64 or a json.Number and normalizes it to a *big.Int, so
+// integer ids larger than 2^53 don't lose precision when the NumberDecoder was used.
+func toBigInt(v interface{}) (*big.Int, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		bi, ok := new(big.Int).SetString(string(n), 10)
+		return bi, ok
+	case float64:
+		bi, _ := big.NewFloat(n).Int(nil)
+		return bi, true
+	}
+	return nil, false
+}
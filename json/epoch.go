@@ -0,0 +1,69 @@
+package json
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// microThreshold distinguishes a bare epoch number given in seconds from one given in
+// microseconds: real-world second-precision timestamps stay well under this for centuries,
+// while a microsecond timestamp for the same era is already well past it.
+const microThreshold = 1e12
+
+// toTime accepts an RFC3339 string, a numeric string, a float64, or a json.Number, and
+// interprets the numeric forms as a Unix epoch timestamp given either in seconds or, for
+// values past microThreshold, microseconds.
+func toTime(v interface{}) (time.Time, error) {
+	s, isString := v.(string)
+	if isString {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, nil
+		}
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		if isString {
+			f, ok = toFloatFromString(s)
+		}
+		if !ok {
+			return time.Time{}, fmt.Errorf("value %v is not an RFC3339 timestamp or an epoch number", v)
+		}
+	}
+	return epochToTime(f), nil
+}
+
+func toFloatFromString(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+func epochToTime(f float64) time.Time {
+	if f < -microThreshold || f > microThreshold {
+		return time.UnixMicro(int64(f)).UTC()
+	}
+	return time.Unix(int64(f), 0).UTC()
+}
+
+// toDuration accepts a Go duration string (e.g. "1h30m"), a bare numeric string, a float64,
+// or a json.Number, and interprets the numeric forms as a number of seconds.
+func toDuration(v interface{}) (time.Duration, error) {
+	if s, ok := v.(string); ok {
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		if s, isString := v.(string); isString {
+			f, ok = toFloatFromString(s)
+		}
+		if !ok {
+			return 0, fmt.Errorf("value %v is not a duration string or a number of seconds", v)
+		}
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
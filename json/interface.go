@@ -0,0 +1,158 @@
+package json
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+type Serializer interface {
+	Bytes() ([]byte, error)
+	MustBytes() []byte
+}
+
+type Array interface {
+	Serializer
+
+	// Len returns the length of the array, or a propagated error
+	Len() (int, error)
+	// String returns the value of a string at index `idx` in the array, or a propagated error
+	String(idx int) (string, error)
+	// Float returns the value of a float at index `idx` in the array, or a propagated error
+	Float(idx int) (float64, error)
+	// Int returns the value of an int at index `idx` in the array, or a propagated error
+	Int(idx int) (int, error)
+	// BigInt returns the value of an integer at index `idx` in the array as a *big.Int,
+	// losslessly, or a propagated error
+	BigInt(idx int) (*big.Int, error)
+	// Number returns the value at index `idx` in the array as a json.Number, or a propagated error
+	Number(idx int) (json.Number, error)
+	// Int64 returns the value of an int64 at index `idx` in the array, or a propagated error.
+	// Accepts either a JSON number or a JSON string containing one, since many upstream APIs
+	// quote large 64-bit ids to survive JavaScript's float64 range.
+	Int64(idx int) (int64, error)
+	// Uint64 returns the value of a uint64 at index `idx` in the array, or a propagated error. See Int64.
+	Uint64(idx int) (uint64, error)
+	// Time returns the value at index `idx` in the array as a time.Time, or a propagated
+	// error. The value may be an RFC3339 string, or an epoch number given in seconds or microseconds.
+	Time(idx int) (time.Time, error)
+	// Duration returns the value at index `idx` in the array as a time.Duration, or a
+	// propagated error. The value may be a Go duration string (e.g. "1h30m"), or a number of seconds.
+	Duration(idx int) (time.Duration, error)
+	// Bool returns the value of a bool at index `idx` in the array, or a propagated error
+	Bool(idx int) (bool, error)
+	// Inner returns the `[]interface{}` which this `Array` represents, or a propagated error
+	Inner() ([]interface{}, error)
+	// Map returns the map found at index `idx` in the array; errors will be propagated
+	Map(idx int) Map
+	// Array returns the array found at index `idx` in the array; errors will be propagated
+	Array(idx int) Array
+
+	// MustLen returns the length of the array, or panics if there was an error
+	MustLen() int
+	// MustString returns the value of a string at index `idx` in the array, or panics if there was an error
+	MustString(idx int) string
+	// MustFloat returns the value of a float at index `idx` in the array, or panics if there was an error
+	MustFloat(idx int) float64
+	// MustInt returns the value of an int at index `idx` in the array, or panics if there was an error
+	MustInt(idx int) int
+	// MustBigInt returns the value of an integer at index `idx` in the array as a *big.Int,
+	// or panics if there was an error
+	MustBigInt(idx int) *big.Int
+	// MustNumber returns the value at index `idx` in the array as a json.Number, or panics if there was an error
+	MustNumber(idx int) json.Number
+	// MustInt64 returns the value of an int64 at index `idx` in the array, or panics if there was an error
+	MustInt64(idx int) int64
+	// MustUint64 returns the value of a uint64 at index `idx` in the array, or panics if there was an error
+	MustUint64(idx int) uint64
+	// MustTime returns the value at index `idx` in the array as a time.Time, or panics if there was an error
+	MustTime(idx int) time.Time
+	// MustDuration returns the value at index `idx` in the array as a time.Duration, or panics if there was an error
+	MustDuration(idx int) time.Duration
+	// MustBool returns the value of a bool at index `idx` in the array, or panics if there was an error
+	MustBool(idx int) bool
+	// MustInner returns the `[]interface{}` which this `Array` represents, or panics if there was an error
+	MustInner() []interface{}
+
+	// Query evaluates a JSONPath expression (e.g. `$[*].name`) against the array and
+	// returns the matching node-set, or a propagated error.
+	Query(expr string) Result
+	// MustQuery evaluates a JSONPath expression, or panics if there was an error.
+	MustQuery(expr string) Result
+
+	// Err returns any error that was found up to this point
+	Err() error
+}
+
+type Map interface {
+	Serializer
+
+	// Has returns true if the map contains the key `key`, or a propagated error
+	Has(key string) (bool, error)
+	// String returns the value of a string at key `key` in the map, or a propagated error
+	String(key string) (string, error)
+	// Float returns the value of a float at key `key` in the map, or a propagated error
+	Float(key string) (float64, error)
+	// Int returns the value of an int at key `key` in the map, or a propagated error
+	Int(key string) (int, error)
+	// BigInt returns the value of an integer at key `key` in the map as a *big.Int,
+	// losslessly, or a propagated error
+	BigInt(key string) (*big.Int, error)
+	// Number returns the value at key `key` in the map as a json.Number, or a propagated error
+	Number(key string) (json.Number, error)
+	// Int64 returns the value of an int64 at key `key` in the map, or a propagated error.
+	// Accepts either a JSON number or a JSON string containing one, since many upstream APIs
+	// quote large 64-bit ids to survive JavaScript's float64 range.
+	Int64(key string) (int64, error)
+	// Uint64 returns the value of a uint64 at key `key` in the map, or a propagated error. See Int64.
+	Uint64(key string) (uint64, error)
+	// Time returns the value at key `key` in the map as a time.Time, or a propagated error.
+	// The value may be an RFC3339 string, or an epoch number given in seconds or microseconds.
+	Time(key string) (time.Time, error)
+	// Duration returns the value at key `key` in the map as a time.Duration, or a propagated
+	// error. The value may be a Go duration string (e.g. "1h30m"), or a number of seconds.
+	Duration(key string) (time.Duration, error)
+	// Bool returns the value of a bool at key `key` in the map, or a propagated error
+	Bool(key string) (bool, error)
+	// Inner returns the `[]interface{}` which this `Array` represents, or a propagated error
+	Inner() (map[string]interface{}, error)
+	// Map returns the map found at key `key` in the map; errors will be propagated
+	Map(key string) Map
+	// Array returns the array found at key `key` in the map; errors will be propagated
+	Array(key string) Array
+
+	// MustHas returns true if the map contains the key `key`, or panics if there was an error
+	MustHas(key string) bool
+	// MustString returns the value of a string at key `key` in the map, or panics if there was an error
+	MustString(key string) string
+	// MustFloat returns the value of a float at key `key` in the map, or panics if there was an error
+	MustFloat(key string) float64
+	// MustInt returns the value of an int at key `key` in the map, or panics if there was an error
+	MustInt(key string) int
+	// MustBigInt returns the value of an integer at key `key` in the map as a *big.Int,
+	// or panics if there was an error
+	MustBigInt(key string) *big.Int
+	// MustNumber returns the value at key `key` in the map as a json.Number, or panics if there was an error
+	MustNumber(key string) json.Number
+	// MustInt64 returns the value of an int64 at key `key` in the map, or panics if there was an error
+	MustInt64(key string) int64
+	// MustUint64 returns the value of a uint64 at key `key` in the map, or panics if there was an error
+	MustUint64(key string) uint64
+	// MustTime returns the value at key `key` in the map as a time.Time, or panics if there was an error
+	MustTime(key string) time.Time
+	// MustDuration returns the value at key `key` in the map as a time.Duration, or panics if there was an error
+	MustDuration(key string) time.Duration
+	// MustBool returns the value of a bool at key `key` in the map, or panics if there was an error
+	MustBool(key string) bool
+	// MustInner returns the `[]interface{}` which this `Array` represents, or panics if there was an error
+	MustInner() map[string]interface{}
+
+	// Query evaluates a JSONPath expression (e.g. `$.name`) against the map and
+	// returns the matching node-set, or a propagated error.
+	Query(expr string) Result
+	// MustQuery evaluates a JSONPath expression, or panics if there was an error.
+	MustQuery(expr string) Result
+
+	// Err returns any error that was found up to this point
+	Err() error
+}
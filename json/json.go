@@ -0,0 +1,929 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// MapFromBytes creates a Map from a []byte, using the default Decoder
+func MapFromBytes(jsonBytes []byte) Map {
+	return MapFromBytesWith(jsonBytes, defaultDecoder)
+}
+
+// MapFromBytesWith creates a Map from a []byte, decoded with the given Decoder
+func MapFromBytesWith(jsonBytes []byte, d Decoder) Map {
+	var v interface{}
+	if err := d.Unmarshal(jsonBytes, &v); err != nil {
+		return errorMap{err}
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return errorMap{fmt.Errorf("decoded value was a %T, not a map", v)}
+	}
+	return valueMap{m}
+}
+
+// MapFromReader creates a Map from an io.Reader, using the default Decoder
+func MapFromReader(r io.Reader) Map {
+	return MapFromReaderWith(r, defaultDecoder)
+}
+
+// MapFromReaderWith creates a Map from an io.Reader, decoded with the given Decoder
+func MapFromReaderWith(r io.Reader, d Decoder) Map {
+	var v interface{}
+	if err := d.NewStreamDecoder(r).Decode(&v); err != nil {
+		return errorMap{err}
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return errorMap{fmt.Errorf("decoded value was a %T, not a map", v)}
+	}
+	return valueMap{m}
+}
+
+// ArrayFromBytes creates an Array from a []byte, using the default Decoder
+func ArrayFromBytes(jsonBytes []byte) Array {
+	return ArrayFromBytesWith(jsonBytes, defaultDecoder)
+}
+
+// ArrayFromBytesWith creates an Array from a []byte, decoded with the given Decoder
+func ArrayFromBytesWith(jsonBytes []byte, d Decoder) Array {
+	var v interface{}
+	if err := d.Unmarshal(jsonBytes, &v); err != nil {
+		return errorArray{err}
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		return errorArray{fmt.Errorf("decoded value was a %T, not an array", v)}
+	}
+	return valueArray{a}
+}
+
+// ArrayFromReader creates an Array from an io.Reader, using the default Decoder
+func ArrayFromReader(r io.Reader) Array {
+	return ArrayFromReaderWith(r, defaultDecoder)
+}
+
+// ArrayFromReaderWith creates an Array from an io.Reader, decoded with the given Decoder
+func ArrayFromReaderWith(r io.Reader, d Decoder) Array {
+	var v interface{}
+	if err := d.NewStreamDecoder(r).Decode(&v); err != nil {
+		return errorArray{err}
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		return errorArray{fmt.Errorf("decoded value was a %T, not an array", v)}
+	}
+	return valueArray{a}
+}
+
+func NewMap(m map[string]interface{}) Map {
+	return valueMap{m}
+}
+
+func NewArray(a []interface{}) Array {
+	return valueArray{a}
+}
+
+// NewErrorMap wraps err in a Map, for sibling packages (e.g. msgpack) that back the Map
+// interface with their own decoder and need to propagate a decode failure the same way
+// MapFromBytes already does.
+func NewErrorMap(err error) Map {
+	return errorMap{err}
+}
+
+// NewErrorArray wraps err in an Array, for sibling packages (e.g. msgpack) that back the Array
+// interface with their own decoder and need to propagate a decode failure the same way
+// ArrayFromBytes already does.
+func NewErrorArray(err error) Array {
+	return errorArray{err}
+}
+
+type valueArray struct {
+	a []interface{}
+}
+
+func (va valueArray) validateIndex(idx int) error {
+	if idx < 0 || idx >= len(va.a) {
+		return fmt.Errorf("invalid index: %d; it should be between 0 and %d", idx, len(va.a)-1)
+	}
+	return nil
+}
+
+func (va valueArray) Map(idx int) Map {
+	err := va.validateIndex(idx)
+	if err != nil {
+		return errorMap{err}
+	}
+	m, ok := va.a[idx].(map[string]interface{})
+	if !ok {
+		return errorMap{
+			err: fmt.Errorf("item at index %d was a %T, not a map", idx, va.a[idx]),
+		}
+	}
+	return valueMap{m}
+}
+
+func (va valueArray) Array(idx int) Array {
+	err := va.validateIndex(idx)
+	if err != nil {
+		return errorArray{err}
+	}
+	a, ok := va.a[idx].([]interface{})
+	if !ok {
+		return errorArray{
+			err: fmt.Errorf("item at index %d was a %T, not an array", idx, va.a[idx]),
+		}
+	}
+	return valueArray{a}
+}
+
+func (va valueArray) MustLen() int {
+	return len(va.a)
+}
+
+func (va valueArray) Items() []interface{} {
+	return va.a
+}
+
+func (va valueArray) String(idx int) (string, error) {
+	err := va.validateIndex(idx)
+	if err != nil {
+		return "", err
+	}
+	s, ok := va.a[idx].(string)
+	if !ok {
+		return "", fmt.Errorf("item at index %d was a %T, not a string", idx, va.a[idx])
+	}
+	return s, nil
+}
+
+func (va valueArray) Float(idx int) (float64, error) {
+	err := va.validateIndex(idx)
+	if err != nil {
+		return 0.0, err
+	}
+	f, ok := toFloat(va.a[idx])
+	if !ok {
+		return 0.0, fmt.Errorf("item at index %d was a %T, not a float", idx, va.a[idx])
+	}
+	return f, nil
+}
+
+func (va valueArray) Int(idx int) (int, error) {
+	f, err := va.Float(idx)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+func (va valueArray) BigInt(idx int) (*big.Int, error) {
+	if err := va.validateIndex(idx); err != nil {
+		return nil, err
+	}
+	bi, ok := toBigInt(va.a[idx])
+	if !ok {
+		return nil, fmt.Errorf("item at index %d was a %T, not an integer", idx, va.a[idx])
+	}
+	return bi, nil
+}
+
+func (va valueArray) Number(idx int) (json.Number, error) {
+	if err := va.validateIndex(idx); err != nil {
+		return "", err
+	}
+	n, ok := toNumber(va.a[idx])
+	if !ok {
+		return "", fmt.Errorf("item at index %d was a %T, not a number", idx, va.a[idx])
+	}
+	return n, nil
+}
+
+func (va valueArray) Int64(idx int) (int64, error) {
+	if err := va.validateIndex(idx); err != nil {
+		return 0, err
+	}
+	i, err := toInt64(va.a[idx])
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return i, nil
+}
+
+func (va valueArray) Uint64(idx int) (uint64, error) {
+	if err := va.validateIndex(idx); err != nil {
+		return 0, err
+	}
+	u, err := toUint64(va.a[idx])
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return u, nil
+}
+
+func (va valueArray) Time(idx int) (time.Time, error) {
+	if err := va.validateIndex(idx); err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(va.a[idx])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return t, nil
+}
+
+func (va valueArray) Duration(idx int) (time.Duration, error) {
+	if err := va.validateIndex(idx); err != nil {
+		return 0, err
+	}
+	d, err := toDuration(va.a[idx])
+	if err != nil {
+		return 0, fmt.Errorf("item at index %d: %w", idx, err)
+	}
+	return d, nil
+}
+
+func (va valueArray) Bool(idx int) (bool, error) {
+	err := va.validateIndex(idx)
+	if err != nil {
+		return false, err
+	}
+	b, ok := va.a[idx].(bool)
+	if !ok {
+		return false, fmt.Errorf("item at index %d was a %T, not a bool", idx, va.a[idx])
+	}
+	return b, nil
+}
+
+func (va valueArray) Bytes() ([]byte, error) {
+	buf, err := json.Marshal(va)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (va valueArray) MustBytes() []byte {
+	result, err := json.Marshal(va.a)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (va valueArray) MustInner() []interface{} {
+	return va.a
+}
+
+func (va valueArray) Len() (int, error) {
+	return len(va.a), nil
+}
+
+func (va valueArray) MustString(idx int) string {
+	s, err := va.String(idx)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (va valueArray) MustFloat(idx int) float64 {
+	f, err := va.Float(idx)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (va valueArray) MustInt(idx int) int {
+	i, err := va.Int(idx)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (va valueArray) MustBigInt(idx int) *big.Int {
+	bi, err := va.BigInt(idx)
+	if err != nil {
+		panic(err)
+	}
+	return bi
+}
+
+func (va valueArray) MustNumber(idx int) json.Number {
+	n, err := va.Number(idx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (va valueArray) MustInt64(idx int) int64 {
+	i, err := va.Int64(idx)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (va valueArray) MustUint64(idx int) uint64 {
+	u, err := va.Uint64(idx)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func (va valueArray) MustTime(idx int) time.Time {
+	t, err := va.Time(idx)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (va valueArray) MustDuration(idx int) time.Duration {
+	d, err := va.Duration(idx)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (va valueArray) MustBool(idx int) bool {
+	b, err := va.Bool(idx)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (va valueArray) Err() error {
+	return nil
+}
+
+// Query evaluates a JSONPath expression (e.g. `$[*].name`) against the array and
+// returns the matching node-set, or a propagated error.
+func (va valueArray) Query(expr string) Result {
+	return runQuery(expr, queryNode{va.a, "$"})
+}
+
+// MustQuery evaluates a JSONPath expression, or panics if there was an error.
+func (va valueArray) MustQuery(expr string) Result {
+	r := va.Query(expr)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+type valueMap struct {
+	m map[string]interface{}
+}
+
+func (vm valueMap) MustHas(key string) bool {
+	_, ok := vm.m[key]
+	return ok
+}
+
+func (vm valueMap) MustString(key string) string {
+	s, err := vm.String(key)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (vm valueMap) MustFloat(key string) float64 {
+	f, err := vm.Float(key)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (vm valueMap) MustInt(key string) int {
+	i, err := vm.Int(key)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (vm valueMap) MustBigInt(key string) *big.Int {
+	bi, err := vm.BigInt(key)
+	if err != nil {
+		panic(err)
+	}
+	return bi
+}
+
+func (vm valueMap) MustNumber(key string) json.Number {
+	n, err := vm.Number(key)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (vm valueMap) MustInt64(key string) int64 {
+	i, err := vm.Int64(key)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (vm valueMap) MustUint64(key string) uint64 {
+	u, err := vm.Uint64(key)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func (vm valueMap) MustTime(key string) time.Time {
+	t, err := vm.Time(key)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (vm valueMap) MustDuration(key string) time.Duration {
+	d, err := vm.Duration(key)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (vm valueMap) MustBool(key string) bool {
+	b, err := vm.Bool(key)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (vm valueMap) Err() error {
+	return nil
+}
+
+// Query evaluates a JSONPath expression (e.g. `$.name`) against the map and
+// returns the matching node-set, or a propagated error.
+func (vm valueMap) Query(expr string) Result {
+	return runQuery(expr, queryNode{vm.m, "$"})
+}
+
+// MustQuery evaluates a JSONPath expression, or panics if there was an error.
+func (vm valueMap) MustQuery(expr string) Result {
+	r := vm.Query(expr)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+func (vm valueMap) validateKey(key string) error {
+	if !vm.MustHas(key) {
+		validKeys := make([]string, 0, len(vm.m))
+		for k, _ := range vm.m {
+			validKeys = append(validKeys, k)
+		}
+		return fmt.Errorf("key not found: %s, valid keys: %+v", key, strings.Join(validKeys, ", "))
+	}
+	return nil
+}
+
+func (vm valueMap) String(key string) (string, error) {
+	if err := vm.validateKey(key); err != nil {
+		return "", err
+	}
+	s, ok := vm.m[key].(string)
+	if !ok {
+		return "", fmt.Errorf("item with key %s was a %T, not a string", key, vm.m[key])
+	}
+	return s, nil
+}
+
+func (vm valueMap) Float(key string) (float64, error) {
+	if err := vm.validateKey(key); err != nil {
+		return 0.0, err
+	}
+	f, ok := toFloat(vm.m[key])
+	if !ok {
+		return 0.0, fmt.Errorf("item with key %s was a %T, not a float", key, vm.m[key])
+	}
+	return f, nil
+}
+
+func (vm valueMap) Int(key string) (int, error) {
+	f, err := vm.Float(key)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+func (vm valueMap) BigInt(key string) (*big.Int, error) {
+	if err := vm.validateKey(key); err != nil {
+		return nil, err
+	}
+	bi, ok := toBigInt(vm.m[key])
+	if !ok {
+		return nil, fmt.Errorf("item with key %s was a %T, not an integer", key, vm.m[key])
+	}
+	return bi, nil
+}
+
+func (vm valueMap) Number(key string) (json.Number, error) {
+	if err := vm.validateKey(key); err != nil {
+		return "", err
+	}
+	n, ok := toNumber(vm.m[key])
+	if !ok {
+		return "", fmt.Errorf("item with key %s was a %T, not a number", key, vm.m[key])
+	}
+	return n, nil
+}
+
+func (vm valueMap) Int64(key string) (int64, error) {
+	if err := vm.validateKey(key); err != nil {
+		return 0, err
+	}
+	i, err := toInt64(vm.m[key])
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return i, nil
+}
+
+func (vm valueMap) Uint64(key string) (uint64, error) {
+	if err := vm.validateKey(key); err != nil {
+		return 0, err
+	}
+	u, err := toUint64(vm.m[key])
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return u, nil
+}
+
+func (vm valueMap) Time(key string) (time.Time, error) {
+	if err := vm.validateKey(key); err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(vm.m[key])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return t, nil
+}
+
+func (vm valueMap) Duration(key string) (time.Duration, error) {
+	if err := vm.validateKey(key); err != nil {
+		return 0, err
+	}
+	d, err := toDuration(vm.m[key])
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s: %w", key, err)
+	}
+	return d, nil
+}
+
+func (vm valueMap) Bool(key string) (bool, error) {
+	if err := vm.validateKey(key); err != nil {
+		return false, err
+	}
+	b, ok := vm.m[key].(bool)
+	if !ok {
+		return false, fmt.Errorf("item with key %s was a %T, not a bool", key, vm.m[key])
+	}
+	return b, nil
+}
+
+func (vm valueMap) Map(key string) Map {
+	if err := vm.validateKey(key); err != nil {
+		return errorMap{err}
+	}
+	m, ok := vm.m[key].(map[string]interface{})
+	if !ok {
+		return errorMap{fmt.Errorf("item with key %s was a %T, not a map", key, vm.m[key])}
+	}
+	return valueMap{m}
+}
+
+func (vm valueMap) Array(key string) Array {
+	if err := vm.validateKey(key); err != nil {
+		return errorArray{err}
+	}
+	a, ok := vm.m[key].([]interface{})
+	if !ok {
+		return errorArray{fmt.Errorf("item with key %s was a %T, not an array", key, vm.m[key])}
+	}
+	return valueArray{a}
+}
+
+func (vm valueMap) Bytes() ([]byte, error) {
+	buf, err := json.Marshal(vm)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (vm valueMap) Has(key string) (bool, error) {
+	_, ok := vm.m[key]
+	return ok, nil
+}
+
+func (vm valueMap) MustBytes() []byte {
+	result, err := json.Marshal(vm.m)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (vm valueMap) Inner() (map[string]interface{}, error) {
+	return vm.m, nil
+}
+
+func (vm valueMap) MustInner() map[string]interface{} {
+	return vm.m
+}
+
+type errorMap struct {
+	err error
+}
+
+func (em errorMap) MustHas(key string) bool {
+	panic(em.err)
+}
+
+func (em errorMap) MustBytes() []byte {
+	panic(em.err)
+}
+
+func (em errorMap) MustInner() map[string]interface{} {
+	panic(em.err)
+}
+
+func (em errorMap) Inner() (map[string]interface{}, error) {
+	return nil, em.err
+}
+
+func (em errorMap) MustString(_ string) string {
+	panic(em.err)
+}
+
+func (em errorMap) MustFloat(_ string) float64 {
+	panic(em.err)
+}
+
+func (em errorMap) MustInt(_ string) int {
+	panic(em.err)
+}
+
+func (em errorMap) MustBigInt(_ string) *big.Int {
+	panic(em.err)
+}
+
+func (em errorMap) MustNumber(_ string) json.Number {
+	panic(em.err)
+}
+
+func (em errorMap) MustBool(_ string) bool {
+	panic(em.err)
+}
+
+func (em errorMap) MustInt64(_ string) int64 {
+	panic(em.err)
+}
+
+func (em errorMap) MustUint64(_ string) uint64 {
+	panic(em.err)
+}
+
+func (em errorMap) MustTime(_ string) time.Time {
+	panic(em.err)
+}
+
+func (em errorMap) MustDuration(_ string) time.Duration {
+	panic(em.err)
+}
+
+func (em errorMap) Err() error {
+	return em.err
+}
+
+func (em errorMap) Query(_ string) Result {
+	return Result{err: em.err}
+}
+
+func (em errorMap) MustQuery(_ string) Result {
+	panic(em.err)
+}
+
+func (em errorMap) Bytes() ([]byte, error) {
+	return nil, em.err
+}
+
+func (em errorMap) Has(_ string) (bool, error) {
+	return false, em.err
+}
+
+func (em errorMap) String(_ string) (string, error) {
+	return "", em.err
+}
+
+func (em errorMap) Float(_ string) (float64, error) {
+	return 0.0, em.err
+}
+
+func (em errorMap) Int(_ string) (int, error) {
+	return 0, em.err
+}
+
+func (em errorMap) BigInt(_ string) (*big.Int, error) {
+	return nil, em.err
+}
+
+func (em errorMap) Number(_ string) (json.Number, error) {
+	return "", em.err
+}
+
+func (em errorMap) Bool(_ string) (bool, error) {
+	return false, em.err
+}
+
+func (em errorMap) Int64(_ string) (int64, error) {
+	return 0, em.err
+}
+
+func (em errorMap) Uint64(_ string) (uint64, error) {
+	return 0, em.err
+}
+
+func (em errorMap) Time(_ string) (time.Time, error) {
+	return time.Time{}, em.err
+}
+
+func (em errorMap) Duration(_ string) (time.Duration, error) {
+	return 0, em.err
+}
+
+func (em errorMap) Map(_ string) Map {
+	return em
+}
+
+func (em errorMap) Array(_ string) Array {
+	return errorArray{
+		em.err,
+	}
+}
+
+type errorArray struct {
+	err error
+}
+
+func (ea errorArray) MustString(_ int) string {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustFloat(_ int) float64 {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustInt(_ int) int {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustBigInt(_ int) *big.Int {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustNumber(_ int) json.Number {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustBool(_ int) bool {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustInt64(_ int) int64 {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustUint64(_ int) uint64 {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustTime(_ int) time.Time {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustDuration(_ int) time.Duration {
+	panic(ea.err)
+}
+
+func (ea errorArray) Err() error {
+	return ea.err
+}
+
+func (ea errorArray) Query(_ string) Result {
+	return Result{err: ea.err}
+}
+
+func (ea errorArray) MustQuery(_ string) Result {
+	panic(ea.err)
+}
+
+func (ea errorArray) Items() []interface{} {
+	return nil
+}
+
+func (ea errorArray) Bytes() ([]byte, error) {
+	return nil, ea.err
+}
+
+func (ea errorArray) MustLen() int {
+	panic(ea.err)
+}
+
+func (ea errorArray) Len() (int, error) {
+	return 0, ea.err
+}
+
+func (ea errorArray) String(_ int) (string, error) {
+	return "", ea.err
+}
+
+func (ea errorArray) Float(_ int) (float64, error) {
+	return 0.0, ea.err
+}
+
+func (ea errorArray) Int(_ int) (int, error) {
+	return 0, ea.err
+}
+
+func (ea errorArray) BigInt(_ int) (*big.Int, error) {
+	return nil, ea.err
+}
+
+func (ea errorArray) Number(_ int) (json.Number, error) {
+	return "", ea.err
+}
+
+func (ea errorArray) Bool(_ int) (bool, error) {
+	return false, ea.err
+}
+
+func (ea errorArray) Int64(_ int) (int64, error) {
+	return 0, ea.err
+}
+
+func (ea errorArray) Uint64(_ int) (uint64, error) {
+	return 0, ea.err
+}
+
+func (ea errorArray) Time(_ int) (time.Time, error) {
+	return time.Time{}, ea.err
+}
+
+func (ea errorArray) Duration(_ int) (time.Duration, error) {
+	return 0, ea.err
+}
+
+func (ea errorArray) Map(_ int) Map {
+	return errorMap{
+		ea.err,
+	}
+}
+
+func (ea errorArray) Array(_ int) Array {
+	return ea
+}
+
+func (ea errorArray) Inner() ([]interface{}, error) {
+	return nil, ea.err
+}
+
+func (va valueArray) Inner() ([]interface{}, error) {
+	return va.a, nil
+}
+
+func (ea errorArray) MustBytes() []byte {
+	panic(ea.err)
+}
+
+func (ea errorArray) MustInner() []interface{} {
+	panic(ea.err)
+}
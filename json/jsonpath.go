@@ -0,0 +1,391 @@
+package json
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// stepKind identifies the kind of selector a single JSONPath step applies.
+type stepKind int
+
+const (
+	stepRoot stepKind = iota
+	stepChild
+	stepIndex
+	stepSlice
+	stepWildcard
+	stepDescendant
+	stepFilter
+)
+
+type sliceArgs struct {
+	start, end, step          int
+	hasStart, hasEnd, hasStep bool
+}
+
+type step struct {
+	kind   stepKind
+	name   string // stepChild, stepDescendant
+	index  int    // stepIndex
+	slice  sliceArgs
+	filter filterNode
+}
+
+// compiledQuery is a JSONPath expression compiled once into a sequence of steps.
+type compiledQuery struct {
+	steps []step
+}
+
+// queryNode is a single matched value together with the path it was found at.
+type queryNode struct {
+	value interface{}
+	path  string
+}
+
+func appendKey(p, key string) string       { return fmt.Sprintf("%s['%s']", p, key) }
+func appendIndex(p string, idx int) string { return fmt.Sprintf("%s[%d]", p, idx) }
+
+// compileQuery compiles a JSONPath expression into a sequence of steps. A leading '$'
+// anchors the expression at the root; it may be omitted when the expression is meant to
+// be evaluated relative to an existing Result (e.g. re-querying with ".title").
+func compileQuery(expr string) (compiledQuery, error) {
+	p := &queryParser{s: expr}
+	if !p.eof() && p.peek() == '$' {
+		p.next()
+	}
+	steps := []step{{kind: stepRoot}}
+	for !p.eof() {
+		s, err := p.parseStep()
+		if err != nil {
+			return compiledQuery{}, err
+		}
+		steps = append(steps, s)
+	}
+	return compiledQuery{steps}, nil
+}
+
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) eof() bool { return p.pos >= len(p.s) }
+
+func (p *queryParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *queryParser) next() byte {
+	b := p.s[p.pos]
+	p.pos++
+	return b
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+func (p *queryParser) readIdent() (string, error) {
+	start := p.pos
+	for !p.eof() && isIdentByte(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", fmt.Errorf("expected identifier at position %d in expression %q", start, p.s)
+	}
+	return p.s[start:p.pos], nil
+}
+
+func (p *queryParser) parseStep() (step, error) {
+	switch p.peek() {
+	case '.':
+		p.next()
+		if p.peek() == '.' {
+			p.next()
+			name, err := p.readIdent()
+			if err != nil {
+				return step{}, err
+			}
+			return step{kind: stepDescendant, name: name}, nil
+		}
+		if p.peek() == '*' {
+			p.next()
+			return step{kind: stepWildcard}, nil
+		}
+		name, err := p.readIdent()
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: stepChild, name: name}, nil
+	case '[':
+		p.next()
+		return p.parseBracket()
+	default:
+		return step{}, fmt.Errorf("unexpected character %q at position %d in expression %q", p.peek(), p.pos, p.s)
+	}
+}
+
+func (p *queryParser) parseBracket() (step, error) {
+	switch p.peek() {
+	case '?':
+		p.next()
+		if p.peek() != '(' {
+			return step{}, fmt.Errorf("expected '(' after '?' in expression %q", p.s)
+		}
+		p.next()
+		start := p.pos
+		depth := 1
+		for !p.eof() && depth > 0 {
+			switch p.next() {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+		}
+		if depth != 0 {
+			return step{}, fmt.Errorf("unterminated filter predicate in expression %q", p.s)
+		}
+		predicate := p.s[start : p.pos-1]
+		if p.eof() || p.peek() != ']' {
+			return step{}, fmt.Errorf("expected ']' to close filter predicate in expression %q", p.s)
+		}
+		p.next()
+		fn, err := parseFilter(predicate)
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: stepFilter, filter: fn}, nil
+	case '*':
+		p.next()
+		if p.eof() || p.next() != ']' {
+			return step{}, fmt.Errorf("expected ']' after '*' in expression %q", p.s)
+		}
+		return step{kind: stepWildcard}, nil
+	case '\'':
+		p.next()
+		start := p.pos
+		for !p.eof() && p.peek() != '\'' {
+			p.pos++
+		}
+		if p.eof() {
+			return step{}, fmt.Errorf("unterminated quoted key in expression %q", p.s)
+		}
+		name := p.s[start:p.pos]
+		p.next()
+		if p.eof() || p.next() != ']' {
+			return step{}, fmt.Errorf("expected ']' after quoted key in expression %q", p.s)
+		}
+		return step{kind: stepChild, name: name}, nil
+	default:
+		start := p.pos
+		for !p.eof() && p.peek() != ']' {
+			p.pos++
+		}
+		if p.eof() {
+			return step{}, fmt.Errorf("unterminated '[' in expression %q", p.s)
+		}
+		token := p.s[start:p.pos]
+		p.next()
+		if strings.Contains(token, ":") {
+			return parseSliceToken(token)
+		}
+		idx, err := strconv.Atoi(token)
+		if err != nil {
+			return step{}, fmt.Errorf("invalid index %q in expression %q", token, p.s)
+		}
+		return step{kind: stepIndex, index: idx}, nil
+	}
+}
+
+func parseSliceToken(token string) (step, error) {
+	parts := strings.Split(token, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return step{}, fmt.Errorf("invalid slice %q", token)
+	}
+	var args sliceArgs
+	if parts[0] != "" {
+		v, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return step{}, fmt.Errorf("invalid slice start %q", parts[0])
+		}
+		args.start, args.hasStart = v, true
+	}
+	if parts[1] != "" {
+		v, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return step{}, fmt.Errorf("invalid slice end %q", parts[1])
+		}
+		args.end, args.hasEnd = v, true
+	}
+	if len(parts) == 3 && parts[2] != "" {
+		v, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return step{}, fmt.Errorf("invalid slice step %q", parts[2])
+		}
+		args.step, args.hasStep = v, true
+	}
+	return step{kind: stepSlice, slice: args}, nil
+}
+
+func evalSteps(steps []step, nodes []queryNode) ([]queryNode, error) {
+	cur := nodes
+	for _, s := range steps {
+		next, err := applyStep(s, cur)
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func applyStep(s step, cur []queryNode) ([]queryNode, error) {
+	var out []queryNode
+	switch s.kind {
+	case stepRoot:
+		return cur, nil
+	case stepChild:
+		for _, n := range cur {
+			m, ok := n.value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			v, ok := m[s.name]
+			if !ok {
+				continue
+			}
+			out = append(out, queryNode{v, appendKey(n.path, s.name)})
+		}
+	case stepIndex:
+		for _, n := range cur {
+			a, ok := n.value.([]interface{})
+			if !ok {
+				continue
+			}
+			idx := s.index
+			if idx < 0 {
+				idx += len(a)
+			}
+			if idx < 0 || idx >= len(a) {
+				continue
+			}
+			out = append(out, queryNode{a[idx], appendIndex(n.path, idx)})
+		}
+	case stepSlice:
+		for _, n := range cur {
+			a, ok := n.value.([]interface{})
+			if !ok {
+				continue
+			}
+			start, end, inc, err := resolveSlice(s.slice, len(a))
+			if err != nil {
+				return nil, err
+			}
+			for i := start; inc > 0 && i < end || inc < 0 && i > end; i += inc {
+				if i < 0 || i >= len(a) {
+					break
+				}
+				out = append(out, queryNode{a[i], appendIndex(n.path, i)})
+			}
+		}
+	case stepWildcard:
+		for _, n := range cur {
+			switch v := n.value.(type) {
+			case map[string]interface{}:
+				for _, k := range sortedKeys(v) {
+					out = append(out, queryNode{v[k], appendKey(n.path, k)})
+				}
+			case []interface{}:
+				for i, item := range v {
+					out = append(out, queryNode{item, appendIndex(n.path, i)})
+				}
+			}
+		}
+	case stepDescendant:
+		for _, n := range cur {
+			collectDescendants(n, s.name, &out)
+		}
+	case stepFilter:
+		for _, n := range cur {
+			a, ok := n.value.([]interface{})
+			if !ok {
+				continue
+			}
+			for i, item := range a {
+				if s.filter.eval(item) {
+					out = append(out, queryNode{item, appendIndex(n.path, i)})
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jsonpath step")
+	}
+	return out, nil
+}
+
+func resolveSlice(args sliceArgs, length int) (start, end, step int, err error) {
+	step = 1
+	if args.hasStep {
+		step = args.step
+	}
+	if step == 0 {
+		return 0, 0, 0, fmt.Errorf("slice step cannot be 0")
+	}
+	normalize := func(i int) int {
+		if i < 0 {
+			i += length
+		}
+		return i
+	}
+	if step > 0 {
+		start = 0
+		end = length
+		if args.hasStart {
+			start = normalize(args.start)
+		}
+		if args.hasEnd {
+			end = normalize(args.end)
+		}
+	} else {
+		start = length - 1
+		end = -1
+		if args.hasStart {
+			start = normalize(args.start)
+		}
+		if args.hasEnd {
+			end = normalize(args.end)
+		}
+	}
+	return start, end, step, nil
+}
+
+func collectDescendants(n queryNode, name string, out *[]queryNode) {
+	switch v := n.value.(type) {
+	case map[string]interface{}:
+		if val, ok := v[name]; ok {
+			*out = append(*out, queryNode{val, appendKey(n.path, name)})
+		}
+		for _, k := range sortedKeys(v) {
+			collectDescendants(queryNode{v[k], appendKey(n.path, k)}, name, out)
+		}
+	case []interface{}:
+		for i, item := range v {
+			collectDescendants(queryNode{item, appendIndex(n.path, i)}, name, out)
+		}
+	}
+}
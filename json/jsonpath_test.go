@@ -0,0 +1,75 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var jsonPathData = []byte(`{
+	"store": {
+		"book": [
+			{"category": "fiction", "title": "Sword", "price": 8.99, "tag": "x"},
+			{"category": "reference", "title": "Guide", "price": 22.99, "tag": "y"},
+			{"category": "fiction", "title": "Map", "price": 9.5, "tag": "x"}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	}
+}`)
+
+func TestQueryDotChild(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	s, err := m.Query("$.store.bicycle.color").AsString()
+	require.NoError(t, err)
+	require.Equal(t, "red", s)
+}
+
+func TestQueryNegativeIndex(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	title, err := m.Query("$.store.book[-1].title").AsString()
+	require.NoError(t, err)
+	require.Equal(t, "Map", title)
+}
+
+func TestQueryWildcard(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	a, err := m.Query("$.store.book[*].title").AsArray()
+	require.NoError(t, err)
+	l, err := a.Len()
+	require.NoError(t, err)
+	require.Equal(t, 3, l)
+}
+
+func TestQueryFilterPredicate(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	a, err := m.Query(`$.store.book[?(@.price < 10 && @.tag == "x")]`).AsArray()
+	require.NoError(t, err)
+	require.Equal(t, 2, a.MustLen())
+}
+
+func TestQueryFilterPredicateWithNumberDecoder(t *testing.T) {
+	m := MapFromBytesWith(jsonPathData, NumberDecoder())
+	a, err := m.Query(`$.store.book[?(@.price < 10 && @.tag == "x")]`).AsArray()
+	require.NoError(t, err)
+	require.Equal(t, 2, a.MustLen())
+}
+
+func TestQueryReQuery(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	titles, err := m.Query("$.store.book[*]").Query(".title").AsArray()
+	require.NoError(t, err)
+	require.Equal(t, 3, titles.MustLen())
+}
+
+func TestQueryPropagatesMapError(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	r := m.Map("missing").Query("$.x")
+	require.Error(t, r.Err())
+}
+
+func TestMustQueryPanics(t *testing.T) {
+	m := MapFromBytes(jsonPathData)
+	require.Panics(t, func() {
+		m.MustQuery("not a jsonpath")
+	})
+}
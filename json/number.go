@@ -0,0 +1,93 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// maxInt64Float is float64(math.MaxInt64) rounded up to the nearest representable value
+// (2^63), which is exactly one past the real maximum; a valid int64 is always < this bound.
+const maxInt64Float = float64(math.MaxInt64)
+
+// toInt64 accepts a float64, a json.Number, or a string containing a number (many upstream
+// APIs quote large 64-bit ids to survive JavaScript's float64 range), and returns a range
+// error for values outside int64 or non-integer floats like 9.345.
+func toInt64(v interface{}) (int64, error) {
+	switch t := v.(type) {
+	case float64:
+		return floatToInt64(t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", string(t))
+		}
+		return floatToInt64(f)
+	case string:
+		if i, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", t)
+		}
+		return floatToInt64(f)
+	default:
+		return 0, fmt.Errorf("value was a %T, not a number", v)
+	}
+}
+
+func floatToInt64(f float64) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("value %v is not an integer", f)
+	}
+	if f < float64(math.MinInt64) || f >= maxInt64Float {
+		return 0, fmt.Errorf("value %v is out of range for int64", f)
+	}
+	return int64(f), nil
+}
+
+// toUint64 accepts a float64, a json.Number, or a string containing a number. See toInt64.
+func toUint64(v interface{}) (uint64, error) {
+	switch t := v.(type) {
+	case float64:
+		return floatToUint64(t)
+	case json.Number:
+		if u, err := t.Int64(); err == nil && u >= 0 {
+			return uint64(u), nil
+		}
+		if u, err := strconv.ParseUint(string(t), 10, 64); err == nil {
+			return u, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", string(t))
+		}
+		return floatToUint64(f)
+	case string:
+		if u, err := strconv.ParseUint(t, 10, 64); err == nil {
+			return u, nil
+		}
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", t)
+		}
+		return floatToUint64(f)
+	default:
+		return 0, fmt.Errorf("value was a %T, not a number", v)
+	}
+}
+
+func floatToUint64(f float64) (uint64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("value %v is not an integer", f)
+	}
+	if f < 0 || f >= float64(math.MaxUint64) {
+		return 0, fmt.Errorf("value %v is out of range for uint64", f)
+	}
+	return uint64(f), nil
+}
@@ -0,0 +1,141 @@
+package json
+
+import "fmt"
+
+// Result is the node-set produced by a JSONPath Query, which may hold zero, one, or many matches.
+type Result struct {
+	nodes []queryNode
+	err   error
+}
+
+func runQuery(expr string, root queryNode) Result {
+	cq, err := compileQuery(expr)
+	if err != nil {
+		return Result{err: err}
+	}
+	nodes, err := evalSteps(cq.steps, []queryNode{root})
+	if err != nil {
+		return Result{err: err}
+	}
+	return Result{nodes: nodes}
+}
+
+// Query re-evaluates a JSONPath expression rooted at each node already matched by this
+// Result, so a complex lookup can be built up one step at a time.
+func (r Result) Query(expr string) Result {
+	if r.err != nil {
+		return r
+	}
+	cq, err := compileQuery(expr)
+	if err != nil {
+		return Result{err: err}
+	}
+	nodes, err := evalSteps(cq.steps, r.nodes)
+	if err != nil {
+		return Result{err: err}
+	}
+	return Result{nodes: nodes}
+}
+
+// Err returns any error that was found up to this point
+func (r Result) Err() error {
+	return r.err
+}
+
+// Len returns the number of values matched by the query, or a propagated error
+func (r Result) Len() (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return len(r.nodes), nil
+}
+
+// MustLen returns the number of values matched by the query, or panics if there was an error
+func (r Result) MustLen() int {
+	n, err := r.Len()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// AsMap returns the single matched value as a Map; it is an error unless the query matched
+// exactly one object.
+func (r Result) AsMap() (Map, error) {
+	if r.err != nil {
+		return errorMap{r.err}, r.err
+	}
+	n, err := r.single()
+	if err != nil {
+		return errorMap{err}, err
+	}
+	m, ok := n.value.(map[string]interface{})
+	if !ok {
+		err := fmt.Errorf("item at path %s was a %T, not a map", n.path, n.value)
+		return errorMap{err}, err
+	}
+	return valueMap{m}, nil
+}
+
+// MustAsMap returns the single matched value as a Map, or panics if there was an error
+func (r Result) MustAsMap() Map {
+	m, err := r.AsMap()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// AsArray bundles every value matched by the query into an Array, preserving match order.
+func (r Result) AsArray() (Array, error) {
+	if r.err != nil {
+		return errorArray{r.err}, r.err
+	}
+	values := make([]interface{}, len(r.nodes))
+	for i, n := range r.nodes {
+		values[i] = n.value
+	}
+	return valueArray{values}, nil
+}
+
+// MustAsArray bundles every value matched by the query into an Array, or panics if there was an error
+func (r Result) MustAsArray() Array {
+	a, err := r.AsArray()
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// AsString returns the single matched value as a string; it is an error unless the query
+// matched exactly one string.
+func (r Result) AsString() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	n, err := r.single()
+	if err != nil {
+		return "", err
+	}
+	s, ok := n.value.(string)
+	if !ok {
+		return "", fmt.Errorf("item at path %s was a %T, not a string", n.path, n.value)
+	}
+	return s, nil
+}
+
+// MustAsString returns the single matched value as a string, or panics if there was an error
+func (r Result) MustAsString() string {
+	s, err := r.AsString()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (r Result) single() (queryNode, error) {
+	if len(r.nodes) != 1 {
+		return queryNode{}, fmt.Errorf("expected exactly 1 query result, got %d", len(r.nodes))
+	}
+	return r.nodes[0], nil
+}
@@ -0,0 +1,264 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// EventKind identifies the kind of token a Stream.Next call produced.
+type EventKind int
+
+const (
+	StartObject EventKind = iota
+	EndObject
+	StartArray
+	EndArray
+	KeyEvent
+	ValueEvent
+)
+
+// Event is a single token-level event produced by Stream.Next.
+type Event struct {
+	Kind EventKind
+	// Key holds the object key for a KeyEvent.
+	Key string
+	// Value holds the decoded scalar, or the whole matched subtree when a registered
+	// OnPath/OnPathArray handler consumed it, for a ValueEvent.
+	Value interface{}
+}
+
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+type streamFrame struct {
+	isArray bool
+	index   int
+	key     string
+}
+
+type pathHandler struct {
+	steps []step
+	mapFn func(Map) error
+	arrFn func(Array) error
+}
+
+// Stream reads a JSON document token-by-token via encoding/json's tokenizer, so multi-GB
+// documents and NDJSON-style feeds can be processed in constant memory instead of being
+// buffered whole into a Map/Array.
+type Stream struct {
+	dec           *json.Decoder
+	stack         []streamFrame
+	awaitingValue bool
+	handlers      []pathHandler
+}
+
+// NewStream creates a Stream that reads JSON tokens from r.
+func NewStream(r io.Reader) *Stream {
+	return &Stream{dec: json.NewDecoder(r), awaitingValue: true}
+}
+
+// OnPath registers handler to be invoked with a Map whenever the stream's current position
+// matches pattern, a JSONPath-style expression such as "$.results[*].user". Only child (.name
+// / ['name']), index ([3]), and wildcard (.* / [*]) steps are supported, since slices,
+// recursive descent, and filters have no well-defined meaning against a single token position.
+func (s *Stream) OnPath(pattern string, handler func(Map) error) error {
+	steps, err := compileStreamPattern(pattern)
+	if err != nil {
+		return err
+	}
+	s.handlers = append(s.handlers, pathHandler{steps: steps, mapFn: handler})
+	return nil
+}
+
+// OnPathArray registers handler to be invoked with an Array whenever the stream's current
+// position matches pattern. See OnPath for the supported pattern grammar.
+func (s *Stream) OnPathArray(pattern string, handler func(Array) error) error {
+	steps, err := compileStreamPattern(pattern)
+	if err != nil {
+		return err
+	}
+	s.handlers = append(s.handlers, pathHandler{steps: steps, arrFn: handler})
+	return nil
+}
+
+func compileStreamPattern(pattern string) ([]step, error) {
+	cq, err := compileQuery(pattern)
+	if err != nil {
+		return nil, err
+	}
+	steps := cq.steps[1:] // drop the leading stepRoot
+	for _, st := range steps {
+		switch st.kind {
+		case stepChild, stepIndex, stepWildcard:
+		default:
+			return nil, fmt.Errorf("pattern %q uses a step unsupported for streaming", pattern)
+		}
+	}
+	return steps, nil
+}
+
+// Run drives the stream to completion, invoking any registered OnPath/OnPathArray handlers as
+// matching subtrees are encountered. It returns the first handler or decode error, or nil once
+// the document is fully consumed.
+func (s *Stream) Run() error {
+	for {
+		_, err := s.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Next advances the stream by one token, returning the event it produced. It returns io.EOF
+// once the document has been fully consumed.
+func (s *Stream) Next() (Event, error) {
+	if s.awaitingValue {
+		if h := s.matchHandler(); h != nil {
+			var v interface{}
+			if err := s.dec.Decode(&v); err != nil {
+				return Event{}, err
+			}
+			if err := h.invoke(v); err != nil {
+				return Event{}, err
+			}
+			s.afterValue()
+			return Event{Kind: ValueEvent, Value: v}, nil
+		}
+	}
+	tok, err := s.dec.Token()
+	if err != nil {
+		return Event{}, err
+	}
+	if delim, ok := tok.(json.Delim); ok {
+		switch delim {
+		case '{':
+			s.stack = append(s.stack, streamFrame{})
+			s.awaitingValue = false
+			return Event{Kind: StartObject}, nil
+		case '[':
+			s.stack = append(s.stack, streamFrame{isArray: true})
+			s.awaitingValue = true
+			return Event{Kind: StartArray}, nil
+		case '}':
+			s.stack = s.stack[:len(s.stack)-1]
+			s.afterValue()
+			return Event{Kind: EndObject}, nil
+		case ']':
+			s.stack = s.stack[:len(s.stack)-1]
+			s.afterValue()
+			return Event{Kind: EndArray}, nil
+		}
+	}
+	if !s.awaitingValue {
+		key := tok.(string)
+		s.stack[len(s.stack)-1].key = key
+		s.awaitingValue = true
+		return Event{Kind: KeyEvent, Key: key}, nil
+	}
+	s.afterValue()
+	return Event{Kind: ValueEvent, Value: tok}, nil
+}
+
+func (s *Stream) afterValue() {
+	if len(s.stack) == 0 {
+		s.awaitingValue = false
+		return
+	}
+	top := &s.stack[len(s.stack)-1]
+	if top.isArray {
+		top.index++
+		s.awaitingValue = true
+	} else {
+		s.awaitingValue = false
+	}
+}
+
+func (s *Stream) currentPath() []pathSegment {
+	path := make([]pathSegment, len(s.stack))
+	for i, f := range s.stack {
+		if f.isArray {
+			path[i] = pathSegment{index: f.index, isIndex: true}
+		} else {
+			path[i] = pathSegment{key: f.key}
+		}
+	}
+	return path
+}
+
+func (s *Stream) matchHandler() *pathHandler {
+	path := s.currentPath()
+	for i := range s.handlers {
+		if matchesStreamPattern(path, s.handlers[i].steps) {
+			return &s.handlers[i]
+		}
+	}
+	return nil
+}
+
+func matchesStreamPattern(path []pathSegment, steps []step) bool {
+	if len(path) != len(steps) {
+		return false
+	}
+	for i, st := range steps {
+		seg := path[i]
+		switch st.kind {
+		case stepWildcard:
+			continue
+		case stepChild:
+			if seg.isIndex || seg.key != st.name {
+				return false
+			}
+		case stepIndex:
+			if !seg.isIndex || seg.index != st.index {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (h pathHandler) invoke(v interface{}) error {
+	if h.mapFn != nil {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("matched subtree was a %T, not a map", v)
+		}
+		return h.mapFn(valueMap{m})
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("matched subtree was a %T, not an array", v)
+	}
+	return h.arrFn(valueArray{a})
+}
+
+// StreamNDJSON reads consecutive, newline- or whitespace-delimited JSON objects from r,
+// invoking handler once per object in document order. It stops and returns nil at a clean
+// EOF, or the first decode or handler error.
+func StreamNDJSON(r io.Reader, handler func(Map) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var v interface{}
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("NDJSON line decoded to a %T, not a map", v)
+		}
+		if err := handler(valueMap{m}); err != nil {
+			return err
+		}
+	}
+}
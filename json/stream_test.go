@@ -0,0 +1,75 @@
+package json
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var streamData = `{
+	"page": 1,
+	"results": [
+		{"user": {"name": "Ada"}, "score": 1},
+		{"user": {"name": "Grace"}, "score": 2}
+	]
+}`
+
+func TestStreamNext(t *testing.T) {
+	s := NewStream(strings.NewReader(`{"a": 1, "b": [2, 3]}`))
+	var kinds []EventKind
+	for {
+		ev, err := s.Next()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+		kinds = append(kinds, ev.Kind)
+	}
+	require.Equal(t, []EventKind{
+		StartObject, KeyEvent, ValueEvent, KeyEvent, StartArray, ValueEvent, ValueEvent, EndArray, EndObject,
+	}, kinds)
+}
+
+func TestStreamOnPath(t *testing.T) {
+	s := NewStream(strings.NewReader(streamData))
+	var names []string
+	err := s.OnPath("$.results[*].user", func(m Map) error {
+		names = append(names, m.MustString("name"))
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Run())
+	require.Equal(t, []string{"Ada", "Grace"}, names)
+}
+
+func TestStreamOnPathArray(t *testing.T) {
+	s := NewStream(strings.NewReader(streamData))
+	var seen bool
+	err := s.OnPathArray("$.results", func(a Array) error {
+		seen = true
+		require.Equal(t, 2, a.MustLen())
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Run())
+	require.True(t, seen)
+}
+
+func TestStreamOnPathRejectsUnsupportedStep(t *testing.T) {
+	s := NewStream(strings.NewReader(streamData))
+	err := s.OnPath("$..user", func(m Map) error { return nil })
+	require.Error(t, err)
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	r := strings.NewReader("{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n")
+	var ids []int
+	err := StreamNDJSON(r, func(m Map) error {
+		ids = append(ids, m.MustInt("id"))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, ids)
+}
@@ -0,0 +1,327 @@
+package luna
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// filterNode evaluates a `[?(...)]` filter predicate against a single array element.
+type filterNode interface {
+	eval(elem interface{}) bool
+}
+
+type andFilter struct{ l, r filterNode }
+
+func (f andFilter) eval(elem interface{}) bool { return f.l.eval(elem) && f.r.eval(elem) }
+
+type orFilter struct{ l, r filterNode }
+
+func (f orFilter) eval(elem interface{}) bool { return f.l.eval(elem) || f.r.eval(elem) }
+
+type notFilter struct{ n filterNode }
+
+func (f notFilter) eval(elem interface{}) bool { return !f.n.eval(elem) }
+
+type existsFilter struct{ operand filterOperand }
+
+func (f existsFilter) eval(elem interface{}) bool {
+	_, ok := f.operand.resolve(elem)
+	return ok
+}
+
+type cmpFilter struct {
+	lhs, rhs filterOperand
+	op       string
+}
+
+func (f cmpFilter) eval(elem interface{}) bool {
+	lv, lok := f.lhs.resolve(elem)
+	rv, rok := f.rhs.resolve(elem)
+	if !lok || !rok {
+		return f.op == "!="
+	}
+	return compareValues(lv, rv, f.op)
+}
+
+// filterOperand is either a literal value or a `@.field.path` reference into the element being tested.
+type filterOperand struct {
+	isField   bool
+	fieldPath []string
+	literal   interface{}
+}
+
+func (o filterOperand) resolve(elem interface{}) (interface{}, bool) {
+	if !o.isField {
+		return o.literal, true
+	}
+	cur := elem
+	for _, key := range o.fieldPath {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		v, ok := m[key]
+		if !ok {
+			return nil, false
+		}
+		cur = v
+	}
+	return cur, true
+}
+
+func compareValues(lv, rv interface{}, op string) bool {
+	switch op {
+	case "==":
+		return valuesEqual(lv, rv)
+	case "!=":
+		return !valuesEqual(lv, rv)
+	default:
+		// toFloat accepts either a float64 (the default decoder) or a json.Number
+		// (NumberDecoder), so a filter predicate works the same way regardless of which
+		// decoder produced the document being queried.
+		if lf, lok := toFloat(lv); lok {
+			if rf, rok := toFloat(rv); rok {
+				return numCompare(lf, rf, op)
+			}
+		}
+		if ls, lok := lv.(string); lok {
+			if rs, rok := rv.(string); rok {
+				return strCompare(ls, rs, op)
+			}
+		}
+		return false
+	}
+}
+
+func valuesEqual(lv, rv interface{}) bool {
+	if lf, lok := toFloat(lv); lok {
+		if rf, rok := toFloat(rv); rok {
+			return lf == rf
+		}
+	}
+	switch l := lv.(type) {
+	case string:
+		r, ok := rv.(string)
+		return ok && l == r
+	case bool:
+		r, ok := rv.(bool)
+		return ok && l == r
+	case nil:
+		return rv == nil
+	default:
+		return false
+	}
+}
+
+func numCompare(l, r float64, op string) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+func strCompare(l, r string, op string) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+func parseFilter(expr string) (filterNode, error) {
+	p := &filterParser{s: expr}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, fmt.Errorf("unexpected trailing input %q in filter %q", p.s[p.pos:], expr)
+	}
+	return n, nil
+}
+
+type filterParser struct {
+	s   string
+	pos int
+}
+
+func (p *filterParser) eof() bool { return p.pos >= len(p.s) }
+
+func (p *filterParser) skipSpace() {
+	for !p.eof() && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *filterParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.s[p.pos:], prefix)
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	lhs, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.hasPrefix("||") {
+			return lhs, nil
+		}
+		p.pos += 2
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		lhs = orFilter{lhs, rhs}
+	}
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.hasPrefix("&&") {
+			return lhs, nil
+		}
+		p.pos += 2
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		lhs = andFilter{lhs, rhs}
+	}
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	p.skipSpace()
+	if !p.eof() && p.s[p.pos] == '!' {
+		p.pos++
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{n}, nil
+	}
+	if !p.eof() && p.s[p.pos] == '(' {
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() || p.s[p.pos] != ')' {
+			return nil, fmt.Errorf("expected ')' in filter %q", p.s)
+		}
+		p.pos++
+		return n, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	lhs, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	op := p.tryParseCmpOp()
+	if op == "" {
+		if !lhs.isField {
+			return nil, fmt.Errorf("literal operand needs a comparison in filter %q", p.s)
+		}
+		return existsFilter{lhs}, nil
+	}
+	p.skipSpace()
+	rhs, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return cmpFilter{lhs, rhs, op}, nil
+}
+
+func (p *filterParser) tryParseCmpOp() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.hasPrefix(op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *filterParser) parseOperand() (filterOperand, error) {
+	p.skipSpace()
+	if p.eof() {
+		return filterOperand{}, fmt.Errorf("unexpected end of filter %q", p.s)
+	}
+	switch {
+	case p.s[p.pos] == '@':
+		p.pos++
+		var fieldPath []string
+		for !p.eof() && p.s[p.pos] == '.' {
+			p.pos++
+			start := p.pos
+			for !p.eof() && isIdentByte(p.s[p.pos]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return filterOperand{}, fmt.Errorf("expected field name after '@.' in filter %q", p.s)
+			}
+			fieldPath = append(fieldPath, p.s[start:p.pos])
+		}
+		return filterOperand{isField: true, fieldPath: fieldPath}, nil
+	case p.s[p.pos] == '\'' || p.s[p.pos] == '"':
+		quote := p.s[p.pos]
+		p.pos++
+		start := p.pos
+		for !p.eof() && p.s[p.pos] != quote {
+			p.pos++
+		}
+		if p.eof() {
+			return filterOperand{}, fmt.Errorf("unterminated string literal in filter %q", p.s)
+		}
+		lit := p.s[start:p.pos]
+		p.pos++
+		return filterOperand{literal: lit}, nil
+	case p.hasPrefix("true"):
+		p.pos += 4
+		return filterOperand{literal: true}, nil
+	case p.hasPrefix("false"):
+		p.pos += 5
+		return filterOperand{literal: false}, nil
+	case p.hasPrefix("null"):
+		p.pos += 4
+		return filterOperand{literal: nil}, nil
+	case p.s[p.pos] == '-' || (p.s[p.pos] >= '0' && p.s[p.pos] <= '9'):
+		start := p.pos
+		p.pos++
+		for !p.eof() && (p.s[p.pos] == '.' || (p.s[p.pos] >= '0' && p.s[p.pos] <= '9')) {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return filterOperand{}, fmt.Errorf("invalid number literal %q in filter %q", p.s[start:p.pos], p.s)
+		}
+		return filterOperand{literal: f}, nil
+	default:
+		return filterOperand{}, fmt.Errorf("unexpected character %q at position %d in filter %q", p.s[p.pos], p.pos, p.s)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"strings"
+	"time"
 )
 
 // Map provides methods to either navigate through the content of a JSON object or propagate any error that has occurred
@@ -18,24 +20,40 @@ func newMapAtRoot() Map {
 	return Map{path: "$"}
 }
 
-// MapFromBytes creates a Map from a []byte
+// MapFromBytes creates a Map from a []byte, using the default Decoder
 func MapFromBytes(jsonBytes []byte) Map {
-	m := newMapAtRoot()
-	err := json.Unmarshal(jsonBytes, &m.m)
-	if err != nil {
+	return MapFromBytesWith(jsonBytes, defaultDecoder)
+}
+
+// MapFromBytesWith creates a Map from a []byte, decoded with the given Decoder
+func MapFromBytesWith(jsonBytes []byte, d Decoder) Map {
+	var v interface{}
+	if err := d.Unmarshal(jsonBytes, &v); err != nil {
 		return Map{nil, "$", err}
 	}
-	return m
+	return mapFromValue(v)
 }
 
-// MapFromReader creates a Map from an io.Reader
+// MapFromReader creates a Map from an io.Reader, using the default Decoder
 func MapFromReader(r io.Reader) Map {
-	m := newMapAtRoot()
-	err := json.NewDecoder(r).Decode(&m.m)
-	if err != nil {
+	return MapFromReaderWith(r, defaultDecoder)
+}
+
+// MapFromReaderWith creates a Map from an io.Reader, decoded with the given Decoder
+func MapFromReaderWith(r io.Reader, d Decoder) Map {
+	var v interface{}
+	if err := d.NewStreamDecoder(r).Decode(&v); err != nil {
 		return Map{nil, "$", err}
 	}
-	return m
+	return mapFromValue(v)
+}
+
+func mapFromValue(v interface{}) Map {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return Map{nil, "$", fmt.Errorf("decoded value was a %T, not a map", v)}
+	}
+	return Map{m, "$", nil}
 }
 
 // NewMap creates a Map from a map[string]interface{}
@@ -54,6 +72,12 @@ func (m Map) Err() error {
 	return m.err
 }
 
+// Path returns the JSONPath-style location of this map within the document it was read from
+// (e.g. "$['people'][0]"), for callers building their own error messages around it.
+func (m Map) Path() string {
+	return string(m.path)
+}
+
 func (m Map) validateKey(key string) error {
 	hasKey, err := m.Has(key)
 	if err != nil {
@@ -84,7 +108,8 @@ func (m Map) String(key string) (string, error) {
 	return s, nil
 }
 
-// Float returns the value of a float at key `key` in the map, or a propagated error
+// Float returns the value of a float at key `key` in the map, or a propagated error. Accepts
+// either a float64 (the default decoder) or a json.Number (the NumberDecoder).
 func (m Map) Float(key string) (float64, error) {
 	if m.err != nil {
 		return 0.0, m.err
@@ -92,13 +117,118 @@ func (m Map) Float(key string) (float64, error) {
 	if err := m.validateKey(key); err != nil {
 		return 0.0, err
 	}
-	f, ok := m.m[key].(float64)
+	f, ok := toFloat(m.m[key])
 	if !ok {
 		return 0.0, fmt.Errorf("item with key %s was a %T, not a float", key, m.m[key])
 	}
 	return f, nil
 }
 
+// Int returns the value of an int at key `key` in the map, or a propagated error
+func (m Map) Int(key string) (int, error) {
+	f, err := m.Float(key)
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+// BigInt returns the value of an integer at key `key` in the map as a *big.Int, or a
+// propagated error. Use this (together with NumberDecoder) instead of Int/Float to read
+// 64-bit ids losslessly, since float64 can't represent every int64 exactly.
+func (m Map) BigInt(key string) (*big.Int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if err := m.validateKey(key); err != nil {
+		return nil, err
+	}
+	bi, ok := toBigInt(m.m[key])
+	if !ok {
+		return nil, fmt.Errorf("item with key %s was a %T, not an integer", key, m.m[key])
+	}
+	return bi, nil
+}
+
+// Number returns the value at key `key` in the map as a json.Number, or a propagated error.
+func (m Map) Number(key string) (json.Number, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	if err := m.validateKey(key); err != nil {
+		return "", err
+	}
+	n, ok := toNumber(m.m[key])
+	if !ok {
+		return "", fmt.Errorf("item with key %s was a %T, not a number", key, m.m[key])
+	}
+	return n, nil
+}
+
+// Int64 returns the value of an int64 at key `key` in the map, or a propagated error. Accepts
+// either a JSON number or a JSON string containing one, since many upstream APIs quote large
+// 64-bit ids to survive JavaScript's float64 range.
+func (m Map) Int64(key string) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	if err := m.validateKey(key); err != nil {
+		return 0, err
+	}
+	i, err := toInt64(m.m[key])
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s at path %s: %w", key, m.path, err)
+	}
+	return i, nil
+}
+
+// Uint64 returns the value of a uint64 at key `key` in the map, or a propagated error. See Int64.
+func (m Map) Uint64(key string) (uint64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	if err := m.validateKey(key); err != nil {
+		return 0, err
+	}
+	u, err := toUint64(m.m[key])
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s at path %s: %w", key, m.path, err)
+	}
+	return u, nil
+}
+
+// Time returns the value at key `key` in the map as a time.Time, or a propagated error. The
+// value may be an RFC3339 string, or an epoch number given in seconds or microseconds.
+func (m Map) Time(key string) (time.Time, error) {
+	if m.err != nil {
+		return time.Time{}, m.err
+	}
+	if err := m.validateKey(key); err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(m.m[key])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("item with key %s at path %s: %w", key, m.path, err)
+	}
+	return t, nil
+}
+
+// Duration returns the value at key `key` in the map as a time.Duration, or a propagated
+// error. The value may be a Go duration string (e.g. "1h30m"), or a number of seconds.
+func (m Map) Duration(key string) (time.Duration, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	if err := m.validateKey(key); err != nil {
+		return 0, err
+	}
+	d, err := toDuration(m.m[key])
+	if err != nil {
+		return 0, fmt.Errorf("item with key %s at path %s: %w", key, m.path, err)
+	}
+	return d, nil
+}
+
 // Bool returns the value of a bool at key `key` in the map, or a propagated error
 func (m Map) Bool(key string) (bool, error) {
 	if m.err != nil {
@@ -151,7 +281,7 @@ func (m Map) Bytes() ([]byte, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
-	buf, err := json.Marshal(m)
+	buf, err := json.Marshal(m.m)
 	if err != nil {
 		return nil, err
 	}
@@ -174,3 +304,122 @@ func (m Map) Inner() (map[string]interface{}, error) {
 	}
 	return m.m, nil
 }
+
+// MustHas returns true if the map contains the key `key`, or panics if there was an error
+func (m Map) MustHas(key string) bool {
+	has, err := m.Has(key)
+	if err != nil {
+		panic(err)
+	}
+	return has
+}
+
+// MustString returns the value of a string at key `key` in the map, or panics if there was an error
+func (m Map) MustString(key string) string {
+	s, err := m.String(key)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// MustFloat returns the value of a float at key `key` in the map, or panics if there was an error
+func (m Map) MustFloat(key string) float64 {
+	f, err := m.Float(key)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// MustInt returns the value of an int at key `key` in the map, or panics if there was an error
+func (m Map) MustInt(key string) int {
+	i, err := m.Int(key)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// MustBigInt returns the value of an integer at key `key` in the map as a *big.Int, or
+// panics if there was an error
+func (m Map) MustBigInt(key string) *big.Int {
+	bi, err := m.BigInt(key)
+	if err != nil {
+		panic(err)
+	}
+	return bi
+}
+
+// MustNumber returns the value at key `key` in the map as a json.Number, or panics if
+// there was an error
+func (m Map) MustNumber(key string) json.Number {
+	n, err := m.Number(key)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// MustInt64 returns the value of an int64 at key `key` in the map, or panics if there was an error
+func (m Map) MustInt64(key string) int64 {
+	i, err := m.Int64(key)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// MustUint64 returns the value of a uint64 at key `key` in the map, or panics if there was an error
+func (m Map) MustUint64(key string) uint64 {
+	u, err := m.Uint64(key)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// MustTime returns the value at key `key` in the map as a time.Time, or panics if there was an error
+func (m Map) MustTime(key string) time.Time {
+	t, err := m.Time(key)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// MustDuration returns the value at key `key` in the map as a time.Duration, or panics if there was an error
+func (m Map) MustDuration(key string) time.Duration {
+	d, err := m.Duration(key)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// MustBool returns the value of a bool at key `key` in the map, or panics if there was an error
+func (m Map) MustBool(key string) bool {
+	b, err := m.Bool(key)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MustBytes returns the serialized value into a slice of bytes, or panics if there was an error
+func (m Map) MustBytes() []byte {
+	b, err := m.Bytes()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MustInner returns the `map[string]interface{}` which this `Map` represents, or panics if there was an error
+func (m Map) MustInner() map[string]interface{} {
+	inner, err := m.Inner()
+	if err != nil {
+		panic(err)
+	}
+	return inner
+}
@@ -0,0 +1,179 @@
+// Package msgpack backs the json package's Map/Array views with MessagePack instead of JSON.
+// Since json.Map and json.Array are just typed views over map[string]interface{} and
+// []interface{}, any format that can be normalized to those shapes can reuse the whole
+// navigation/JSONPath/mutation surface without a second library.
+package msgpack
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mikecoop83/luna/json"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MapFromBytes creates a json.Map from MessagePack-encoded bytes.
+func MapFromBytes(data []byte) json.Map {
+	v, err := decode(data)
+	if err != nil {
+		return json.NewErrorMap(err)
+	}
+	return mapFromValue(v)
+}
+
+// MapFromReader creates a json.Map from a MessagePack-encoded io.Reader.
+func MapFromReader(r io.Reader) json.Map {
+	v, err := decodeReader(r)
+	if err != nil {
+		return json.NewErrorMap(err)
+	}
+	return mapFromValue(v)
+}
+
+// ArrayFromBytes creates a json.Array from MessagePack-encoded bytes.
+func ArrayFromBytes(data []byte) json.Array {
+	v, err := decode(data)
+	if err != nil {
+		return json.NewErrorArray(err)
+	}
+	return arrayFromValue(v)
+}
+
+// ArrayFromReader creates a json.Array from a MessagePack-encoded io.Reader.
+func ArrayFromReader(r io.Reader) json.Array {
+	v, err := decodeReader(r)
+	if err != nil {
+		return json.NewErrorArray(err)
+	}
+	return arrayFromValue(v)
+}
+
+// Bytes MessagePack-encodes m, the symmetric counterpart to MapFromBytes.
+func Bytes(m json.Map) ([]byte, error) {
+	inner, err := m.Inner()
+	if err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(denormalize(inner))
+}
+
+// ArrayBytes MessagePack-encodes a, the symmetric counterpart to ArrayFromBytes.
+func ArrayBytes(a json.Array) ([]byte, error) {
+	inner, err := a.Inner()
+	if err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(denormalize(inner))
+}
+
+func decode(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := msgpack.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return normalize(v), nil
+}
+
+func decodeReader(r io.Reader) (interface{}, error) {
+	var v interface{}
+	if err := msgpack.NewDecoder(r).Decode(&v); err != nil {
+		return nil, err
+	}
+	return normalize(v), nil
+}
+
+func mapFromValue(v interface{}) json.Map {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return json.NewErrorMap(fmt.Errorf("decoded value was a %T, not a map", v))
+	}
+	return json.NewMap(m)
+}
+
+func arrayFromValue(v interface{}) json.Array {
+	a, ok := v.([]interface{})
+	if !ok {
+		return json.NewErrorArray(fmt.Errorf("decoded value was a %T, not an array", v))
+	}
+	return json.NewArray(a)
+}
+
+// normalize walks a decoded MessagePack value, converting map[interface{}]interface{} keys to
+// strings and integer scalars to json.Number, so the result matches the shapes json.Map/Array
+// already expect from the JSON decoder - and, critically, so ids above 2^53 (which msgpack,
+// unlike JSON, can represent exactly as int64/uint64) survive the round trip losslessly via
+// BigInt/Number/Int64/Uint64, the same way NumberDecoder represents large ids for the JSON
+// path. Converting straight to float64 here would silently reintroduce the precision loss
+// that machinery exists to avoid.
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = normalize(val)
+		}
+		return t
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprintf("%v", k)] = normalize(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range t {
+			t[i] = normalize(val)
+		}
+		return t
+	case int8:
+		return stdjson.Number(strconv.FormatInt(int64(t), 10))
+	case int16:
+		return stdjson.Number(strconv.FormatInt(int64(t), 10))
+	case int32:
+		return stdjson.Number(strconv.FormatInt(int64(t), 10))
+	case int64:
+		return stdjson.Number(strconv.FormatInt(t, 10))
+	case uint8:
+		return stdjson.Number(strconv.FormatUint(uint64(t), 10))
+	case uint16:
+		return stdjson.Number(strconv.FormatUint(uint64(t), 10))
+	case uint32:
+		return stdjson.Number(strconv.FormatUint(uint64(t), 10))
+	case uint64:
+		return stdjson.Number(strconv.FormatUint(t, 10))
+	default:
+		return v
+	}
+}
+
+// denormalize is normalize's counterpart for Bytes/ArrayBytes: it converts the json.Number
+// values normalize produced back into an integer msgpack can encode natively (int64, falling
+// back to uint64 for ids above math.MaxInt64), so a decode/encode round trip through this
+// package preserves large ids exactly instead of re-widening them into a lossy float64.
+func denormalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = denormalize(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = denormalize(val)
+		}
+		return out
+	case stdjson.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(string(t), 10, 64); err == nil {
+			return u
+		}
+		f, _ := t.Float64()
+		return f
+	default:
+		return v
+	}
+}
@@ -0,0 +1,89 @@
+package msgpack
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vmsgpack "github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMapFromBytes(t *testing.T) {
+	data, err := vmsgpack.Marshal(map[string]interface{}{
+		"name":  "Ada",
+		"score": int64(42),
+		"tags":  []interface{}{"a", "b"},
+	})
+	require.NoError(t, err)
+
+	m := MapFromBytes(data)
+	name, err := m.String("name")
+	require.NoError(t, err)
+	require.Equal(t, "Ada", name)
+
+	score, err := m.Int("score")
+	require.NoError(t, err)
+	require.Equal(t, 42, score)
+
+	tags, err := m.Array("tags").Len()
+	require.NoError(t, err)
+	require.Equal(t, 2, tags)
+}
+
+func TestArrayFromBytes(t *testing.T) {
+	data, err := vmsgpack.Marshal([]interface{}{1, 2, 3})
+	require.NoError(t, err)
+
+	a := ArrayFromBytes(data)
+	l, err := a.Len()
+	require.NoError(t, err)
+	require.Equal(t, 3, l)
+}
+
+func TestMapFromBytesPropagatesDecodeError(t *testing.T) {
+	m := MapFromBytes([]byte{0xff, 0xff, 0xff})
+	require.Error(t, m.Err())
+}
+
+func TestBytesRoundTrips(t *testing.T) {
+	data, err := vmsgpack.Marshal(map[string]interface{}{"id": int64(7)})
+	require.NoError(t, err)
+	m := MapFromBytes(data)
+
+	out, err := Bytes(m)
+	require.NoError(t, err)
+
+	m2 := MapFromBytes(out)
+	id, err := m2.Int("id")
+	require.NoError(t, err)
+	require.Equal(t, 7, id)
+}
+
+func TestBigIntSurvivesBeyondFloat64Precision(t *testing.T) {
+	data, err := vmsgpack.Marshal(map[string]interface{}{"id": int64(math.MaxInt64)})
+	require.NoError(t, err)
+
+	m := MapFromBytes(data)
+	bi, err := m.BigInt("id")
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).SetInt64(math.MaxInt64), bi)
+
+	n, err := m.Number("id")
+	require.NoError(t, err)
+	require.Equal(t, "9223372036854775807", n.String())
+}
+
+func TestBigIntRoundTripsThroughBytes(t *testing.T) {
+	data, err := vmsgpack.Marshal(map[string]interface{}{"id": uint64(math.MaxInt64) + 1})
+	require.NoError(t, err)
+	m := MapFromBytes(data)
+
+	out, err := Bytes(m)
+	require.NoError(t, err)
+
+	m2 := MapFromBytes(out)
+	n, err := m2.Number("id")
+	require.NoError(t, err)
+	require.Equal(t, "9223372036854775808", n.String())
+}
@@ -0,0 +1,261 @@
+package luna
+
+import "fmt"
+
+// compileMutationPath compiles a dotted/bracketed path (the same grammar Query uses, e.g.
+// "user.address.zip" or "$.tags[0]") into a sequence of child/index steps. A bare leading
+// segment with no '$', '.', or '[' is treated as a child step, so "user.zip" behaves the same
+// as ".user.zip". Wildcards, slices, recursive descent, and filters have no well-defined
+// meaning for a single write and are rejected.
+func compileMutationPath(path string) ([]step, error) {
+	if path != "" {
+		switch path[0] {
+		case '$', '.', '[':
+		default:
+			path = "." + path
+		}
+	}
+	cq, err := compileQuery(path)
+	if err != nil {
+		return nil, err
+	}
+	steps := cq.steps[1:]
+	for _, st := range steps {
+		switch st.kind {
+		case stepChild, stepIndex:
+		default:
+			return nil, fmt.Errorf("path %q uses a step unsupported for Set/Delete/Append", path)
+		}
+	}
+	return steps, nil
+}
+
+// applyMutation walks steps against cur, auto-creating intermediate objects for missing child
+// steps, then invokes op with the container that directly holds the final step (a
+// map[string]interface{} for a child step, or a []interface{} for an index step) together
+// with that final step. op returns the (possibly different, e.g. resized) container to write
+// back in its parent's place.
+func applyMutation(cur interface{}, steps []step, op func(parent interface{}, last step) (interface{}, error)) (interface{}, error) {
+	if len(steps) == 1 {
+		return op(cur, steps[0])
+	}
+	head, rest := steps[0], steps[1:]
+	switch head.kind {
+	case stepChild:
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot navigate field %q on a %T", head.name, cur)
+		}
+		child, exists := m[head.name]
+		if !exists || child == nil {
+			child = defaultContainer(rest[0])
+		}
+		newChild, err := applyMutation(child, rest, op)
+		if err != nil {
+			return nil, err
+		}
+		m[head.name] = newChild
+		return m, nil
+	case stepIndex:
+		a, ok := cur.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot navigate index %d on a %T", head.index, cur)
+		}
+		idx, a, err := resolveMutationIndex(a, head.index)
+		if err != nil {
+			return nil, err
+		}
+		child := a[idx]
+		if child == nil {
+			child = defaultContainer(rest[0])
+		}
+		newChild, err := applyMutation(child, rest, op)
+		if err != nil {
+			return nil, err
+		}
+		a[idx] = newChild
+		return a, nil
+	default:
+		return nil, fmt.Errorf("unsupported mutation step")
+	}
+}
+
+// defaultContainer is the empty value a missing intermediate step is filled in with, chosen to
+// match whatever kind of step comes next: an index step descends into an array, anything else
+// into an object.
+func defaultContainer(next step) interface{} {
+	if next.kind == stepIndex {
+		return []interface{}{}
+	}
+	return map[string]interface{}{}
+}
+
+// resolveMutationIndex adjusts idx against a's current length the same way the read path does
+// (negative indices count from the end), except -1 is special-cased to mean "append": it grows
+// a by one nil placeholder and returns that new slot's index, mirroring sjson's Set semantics.
+func resolveMutationIndex(a []interface{}, idx int) (int, []interface{}, error) {
+	if idx == -1 {
+		return len(a), append(a, nil), nil
+	}
+	adjusted := idx
+	if adjusted < 0 {
+		adjusted += len(a)
+	}
+	if adjusted < 0 || adjusted >= len(a) {
+		return 0, nil, fmt.Errorf("index %d out of range for array of length %d", idx, len(a))
+	}
+	return adjusted, a, nil
+}
+
+func setOp(value interface{}) func(interface{}, step) (interface{}, error) {
+	return func(parent interface{}, last step) (interface{}, error) {
+		switch last.kind {
+		case stepChild:
+			m, ok := parent.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("cannot set field %q on a %T", last.name, parent)
+			}
+			m[last.name] = value
+			return m, nil
+		case stepIndex:
+			a, ok := parent.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("cannot set index %d on a %T", last.index, parent)
+			}
+			idx, a, err := resolveMutationIndex(a, last.index)
+			if err != nil {
+				return nil, err
+			}
+			a[idx] = value
+			return a, nil
+		default:
+			return nil, fmt.Errorf("unsupported step for Set")
+		}
+	}
+}
+
+func deleteOp(parent interface{}, last step) (interface{}, error) {
+	switch last.kind {
+	case stepChild:
+		m, ok := parent.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot delete field %q from a %T", last.name, parent)
+		}
+		delete(m, last.name)
+		return m, nil
+	case stepIndex:
+		a, ok := parent.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot delete index %d from a %T", last.index, parent)
+		}
+		idx := last.index
+		if idx < 0 {
+			idx += len(a)
+		}
+		if idx < 0 || idx >= len(a) {
+			return a, nil
+		}
+		out := make([]interface{}, 0, len(a)-1)
+		out = append(out, a[:idx]...)
+		out = append(out, a[idx+1:]...)
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported step for Delete")
+	}
+}
+
+func appendOp(value interface{}) func(interface{}, step) (interface{}, error) {
+	return func(parent interface{}, last step) (interface{}, error) {
+		if last.kind != stepChild {
+			return nil, fmt.Errorf("Append requires a field path naming the array to extend")
+		}
+		m, ok := parent.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot append to field %q on a %T", last.name, parent)
+		}
+		existing := m[last.name]
+		var a []interface{}
+		if existing != nil {
+			var ok2 bool
+			a, ok2 = existing.([]interface{})
+			if !ok2 {
+				return nil, fmt.Errorf("field %q is a %T, not an array", last.name, existing)
+			}
+		}
+		m[last.name] = append(a, value)
+		return m, nil
+	}
+}
+
+// Set assigns value at path, which uses the same dotted/bracketed grammar as Query, creating
+// any missing intermediate objects along the way. It returns m so calls can be chained; any
+// error is propagated the same way read errors already are.
+func (m Map) Set(path string, value interface{}) Map {
+	return m.mutate(path, setOp(value))
+}
+
+// Delete removes the field or array element addressed by path. It returns m so calls can be
+// chained; deleting a path that doesn't exist is not an error.
+func (m Map) Delete(path string) Map {
+	return m.mutate(path, deleteOp)
+}
+
+// Append appends value to the array addressed by path, creating it if it doesn't exist yet.
+func (m Map) Append(path string, value interface{}) Map {
+	return m.mutate(path, appendOp(value))
+}
+
+func (m Map) mutate(path string, op func(parent interface{}, last step) (interface{}, error)) Map {
+	if m.err != nil {
+		return m
+	}
+	steps, err := compileMutationPath(path)
+	if err != nil {
+		return Map{nil, m.path, err}
+	}
+	if len(steps) == 0 {
+		return Map{nil, m.path, fmt.Errorf("path %q does not select a field", path)}
+	}
+	newRoot, err := applyMutation(m.m, steps, op)
+	if err != nil {
+		return Map{nil, m.path, err}
+	}
+	m.m = newRoot.(map[string]interface{})
+	return m
+}
+
+// Set assigns value at path, which uses the same dotted/bracketed grammar as Query, creating
+// any missing intermediate objects along the way. It returns a so calls can be chained.
+func (a Array) Set(path string, value interface{}) Array {
+	return a.mutate(path, setOp(value))
+}
+
+// Delete removes the field or array element addressed by path. It returns a so calls can be
+// chained; deleting a path that doesn't exist is not an error.
+func (a Array) Delete(path string) Array {
+	return a.mutate(path, deleteOp)
+}
+
+// Append appends value to the array addressed by path, creating it if it doesn't exist yet.
+func (a Array) Append(path string, value interface{}) Array {
+	return a.mutate(path, appendOp(value))
+}
+
+func (a Array) mutate(path string, op func(parent interface{}, last step) (interface{}, error)) Array {
+	if a.err != nil {
+		return a
+	}
+	steps, err := compileMutationPath(path)
+	if err != nil {
+		return Array{nil, a.path, err}
+	}
+	if len(steps) == 0 {
+		return Array{nil, a.path, fmt.Errorf("path %q does not select a field", path)}
+	}
+	newRoot, err := applyMutation(a.a, steps, op)
+	if err != nil {
+		return Array{nil, a.path, err}
+	}
+	a.a = newRoot.([]interface{})
+	return a
+}
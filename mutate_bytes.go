@@ -0,0 +1,372 @@
+package luna
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SetBytes behaves like Map.Set, but operates directly on raw JSON bytes: when every step of
+// path already exists, it splices the re-encoded value into the original buffer in place of
+// the old one instead of decoding the whole document, mutating a tree, and re-encoding it.
+// This keeps edits to a single field of a large document cheap regardless of document size.
+// When any step is missing (so a new field, array slot, or intermediate container would need
+// to be created), it falls back to the decode/mutate/encode path, since splicing can't create
+// structure that isn't already there.
+func SetBytes(jsonBytes []byte, path string, value interface{}) ([]byte, error) {
+	steps, err := compileMutationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("path %q does not select a field", path)
+	}
+	if span, ok, err := locateSpan(jsonBytes, steps); err != nil {
+		return nil, err
+	} else if ok {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return spliceBytes(jsonBytes, span, encoded), nil
+	}
+	return MapFromBytes(jsonBytes).Set(path, value).Bytes()
+}
+
+// DeleteBytes behaves like Map.Delete, but operates directly on raw JSON bytes: when path
+// exists, it removes the matched field or array element (along with the comma that separated
+// it from its neighbor) from the original buffer instead of decoding, mutating, and
+// re-encoding the whole document. Deleting a path that doesn't exist is not an error, and
+// falls back to the decode/mutate/encode path since there is no in-place edit to make.
+func DeleteBytes(jsonBytes []byte, path string) ([]byte, error) {
+	steps, err := compileMutationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("path %q does not select a field", path)
+	}
+	span, ok, err := locateEntrySpan(jsonBytes, steps)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return MapFromBytes(jsonBytes).Delete(path).Bytes()
+	}
+	return spliceBytes(jsonBytes, span, nil), nil
+}
+
+func spliceBytes(data []byte, span byteSpan, replacement []byte) []byte {
+	out := make([]byte, 0, len(data)-(span.end-span.start)+len(replacement))
+	out = append(out, data[:span.start]...)
+	out = append(out, replacement...)
+	out = append(out, data[span.end:]...)
+	return out
+}
+
+// byteSpan identifies a [start, end) range of bytes within a raw JSON document.
+type byteSpan struct {
+	start, end int
+}
+
+// locateSpan walks steps directly against the raw bytes of data (which must hold a single
+// top-level JSON value), returning the span of the value steps addresses. ok is false if any
+// step along the way doesn't exist, in which case the caller should fall back to decoding.
+func locateSpan(data []byte, steps []step) (byteSpan, bool, error) {
+	i := skipJSONWS(data, 0)
+	valStart, valEnd, err := scanJSONValue(data, i)
+	if err != nil {
+		return byteSpan{}, false, err
+	}
+	span := byteSpan{valStart, valEnd}
+	for _, st := range steps {
+		var found bool
+		switch st.kind {
+		case stepChild:
+			span, found, err = findObjectMember(data, span, st.name)
+		case stepIndex:
+			span, found, err = findArrayElement(data, span, st.index)
+		default:
+			return byteSpan{}, false, fmt.Errorf("unsupported mutation step")
+		}
+		if err != nil || !found {
+			return byteSpan{}, false, err
+		}
+	}
+	return span, true, nil
+}
+
+// locateEntrySpan is locateSpan's counterpart for Delete: it returns the span of the whole
+// entry (the "key":value pair, or array element) named by the last step, extended to also
+// consume one adjacent comma so removing it leaves the surrounding container well-formed.
+func locateEntrySpan(data []byte, steps []step) (byteSpan, bool, error) {
+	containerSpan, ok, err := locateSpan(data, steps[:len(steps)-1])
+	if err != nil || !ok {
+		return byteSpan{}, false, err
+	}
+	last := steps[len(steps)-1]
+	switch last.kind {
+	case stepChild:
+		return findObjectEntry(data, containerSpan, last.name)
+	case stepIndex:
+		return findArrayEntry(data, containerSpan, last.index)
+	default:
+		return byteSpan{}, false, fmt.Errorf("unsupported mutation step")
+	}
+}
+
+func skipJSONWS(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanJSONValue returns the [start, end) span of the JSON value beginning at or after i,
+// skipping any leading whitespace.
+func scanJSONValue(data []byte, i int) (start, end int, err error) {
+	i = skipJSONWS(data, i)
+	if i >= len(data) {
+		return 0, 0, fmt.Errorf("unexpected end of JSON input")
+	}
+	start = i
+	switch data[i] {
+	case '"':
+		end, err = scanJSONString(data, i)
+	case '{':
+		end, err = scanJSONContainer(data, i, '{', '}')
+	case '[':
+		end, err = scanJSONContainer(data, i, '[', ']')
+	default:
+		end = i
+		for end < len(data) {
+			switch data[end] {
+			case ',', '}', ']', ' ', '\t', '\r', '\n':
+				goto done
+			}
+			end++
+		}
+	done:
+		if end == start {
+			return 0, 0, fmt.Errorf("unexpected character %q in JSON input", data[i])
+		}
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// scanJSONString returns the end of the string literal starting at i (where data[i] == '"').
+func scanJSONString(data []byte, i int) (int, error) {
+	j := i + 1
+	for j < len(data) {
+		switch data[j] {
+		case '\\':
+			j += 2
+			continue
+		case '"':
+			return j + 1, nil
+		}
+		j++
+	}
+	return 0, fmt.Errorf("unterminated string in JSON input")
+}
+
+// scanJSONContainer returns the end of the object/array starting at i (where data[i] == open),
+// skipping over nested containers and strings.
+func scanJSONContainer(data []byte, i int, open, close byte) (int, error) {
+	depth := 0
+	for j := i; j < len(data); j++ {
+		switch data[j] {
+		case '"':
+			end, err := scanJSONString(data, j)
+			if err != nil {
+				return 0, err
+			}
+			j = end - 1
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return j + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unterminated %q in JSON input", open)
+}
+
+// objectMember is one "key": value pair found by scanJSONObjectMembers. entry spans the pair
+// itself (from the opening quote of the key to the end of the value), excluding any
+// surrounding comma.
+type objectMember struct {
+	name      string
+	valueSpan byteSpan
+	entry     byteSpan
+}
+
+// scanJSONObjectMembers returns every member of the object spanning objSpan, in document
+// order. It returns a nil slice (with no error) if objSpan isn't an object.
+func scanJSONObjectMembers(data []byte, objSpan byteSpan) ([]objectMember, error) {
+	i := skipJSONWS(data, objSpan.start)
+	if i >= objSpan.end || data[i] != '{' {
+		return nil, nil
+	}
+	var members []objectMember
+	i = skipJSONWS(data, i+1)
+	for i < objSpan.end && data[i] != '}' {
+		entryStart := i
+		keyStart, keyEnd, err := scanJSONValue(data, i)
+		if err != nil {
+			return nil, err
+		}
+		name, err := unquoteJSONString(data[keyStart:keyEnd])
+		if err != nil {
+			return nil, err
+		}
+		i = skipJSONWS(data, keyEnd)
+		if i >= objSpan.end || data[i] != ':' {
+			return nil, fmt.Errorf("expected ':' in JSON object")
+		}
+		valStart, valEnd, err := scanJSONValue(data, i+1)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, objectMember{name, byteSpan{valStart, valEnd}, byteSpan{entryStart, valEnd}})
+		i = skipJSONWS(data, valEnd)
+		if i < objSpan.end && data[i] == ',' {
+			i = skipJSONWS(data, i+1)
+		}
+	}
+	return members, nil
+}
+
+// findObjectMember scans the object spanning objSpan for a member named key, returning the
+// span of its value. found is false (with no error) if objSpan isn't an object or key isn't
+// present.
+func findObjectMember(data []byte, objSpan byteSpan, key string) (byteSpan, bool, error) {
+	members, err := scanJSONObjectMembers(data, objSpan)
+	if err != nil {
+		return byteSpan{}, false, err
+	}
+	for _, mem := range members {
+		if mem.name == key {
+			return mem.valueSpan, true, nil
+		}
+	}
+	return byteSpan{}, false, nil
+}
+
+// findArrayElement scans the array spanning arrSpan for element idx (supporting the same
+// negative-from-end indexing as the rest of the package), returning its span. found is false
+// (with no error) if arrSpan isn't an array or idx is out of range. idx == -1 (Set's "append"
+// sentinel) always reports not found, since splicing a span in place can only replace an
+// existing value, not insert a new one; the caller falls back to the decode/mutate/encode path
+// for that case.
+func findArrayElement(data []byte, arrSpan byteSpan, idx int) (byteSpan, bool, error) {
+	if idx == -1 {
+		return byteSpan{}, false, nil
+	}
+	elems, err := scanJSONElements(data, arrSpan)
+	if err != nil {
+		return byteSpan{}, false, err
+	}
+	resolved := idx
+	if resolved < 0 {
+		resolved += len(elems)
+	}
+	if resolved < 0 || resolved >= len(elems) {
+		return byteSpan{}, false, nil
+	}
+	return elems[resolved], true, nil
+}
+
+func scanJSONElements(data []byte, arrSpan byteSpan) ([]byteSpan, error) {
+	i := skipJSONWS(data, arrSpan.start)
+	if i >= arrSpan.end || data[i] != '[' {
+		return nil, nil
+	}
+	var elems []byteSpan
+	i = skipJSONWS(data, i+1)
+	for i < arrSpan.end && data[i] != ']' {
+		valStart, valEnd, err := scanJSONValue(data, i)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, byteSpan{valStart, valEnd})
+		i = skipJSONWS(data, valEnd)
+		if i < arrSpan.end && data[i] == ',' {
+			i = skipJSONWS(data, i+1)
+		}
+	}
+	return elems, nil
+}
+
+// findObjectEntry is findObjectMember's Delete counterpart: it returns the span of the whole
+// "key":value entry, extended to also consume one adjacent comma (the one following it, or
+// failing that the one preceding it) so the remaining members stay well-formed.
+func findObjectEntry(data []byte, objSpan byteSpan, key string) (byteSpan, bool, error) {
+	members, err := scanJSONObjectMembers(data, objSpan)
+	if err != nil {
+		return byteSpan{}, false, err
+	}
+	for i, mem := range members {
+		if mem.name != key {
+			continue
+		}
+		if i+1 < len(members) {
+			afterComma := skipJSONWS(data, mem.entry.end)
+			return byteSpan{mem.entry.start, skipJSONWS(data, afterComma+1)}, true, nil
+		}
+		if i > 0 {
+			return byteSpan{members[i-1].entry.end, mem.entry.end}, true, nil
+		}
+		return mem.entry, true, nil
+	}
+	return byteSpan{}, false, nil
+}
+
+// findArrayEntry is findArrayElement's Delete counterpart: it returns the span of the whole
+// array element, extended to also consume one adjacent comma.
+func findArrayEntry(data []byte, arrSpan byteSpan, idx int) (byteSpan, bool, error) {
+	i := skipJSONWS(data, arrSpan.start)
+	if i >= arrSpan.end || data[i] != '[' {
+		return byteSpan{}, false, nil
+	}
+	elems, err := scanJSONElements(data, arrSpan)
+	if err != nil {
+		return byteSpan{}, false, err
+	}
+	resolved := idx
+	if resolved < 0 {
+		resolved += len(elems)
+	}
+	if resolved < 0 || resolved >= len(elems) {
+		return byteSpan{}, false, nil
+	}
+	entry := elems[resolved]
+	if resolved+1 < len(elems) {
+		afterComma := skipJSONWS(data, entry.end)
+		return byteSpan{entry.start, skipJSONWS(data, afterComma+1)}, true, nil
+	}
+	if resolved > 0 {
+		before := elems[resolved-1]
+		return byteSpan{before.end, entry.end}, true, nil
+	}
+	return entry, true, nil
+}
+
+// unquoteJSONString decodes a raw (still-quoted) JSON string literal, as found by
+// scanJSONValue, into its Go string value.
+func unquoteJSONString(raw []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", fmt.Errorf("invalid JSON string %s: %w", raw, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,66 @@
+package luna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBytesReplacesExistingNestedField(t *testing.T) {
+	out, err := SetBytes([]byte(`{"a": 1, "b": {"c": 2}, "d": [1, 2, 3]}`), "b.c", 99)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": 1, "b": {"c": 99}, "d": [1, 2, 3]}`, string(out))
+}
+
+func TestSetBytesReplacesArrayElement(t *testing.T) {
+	out, err := SetBytes([]byte(`{"tags": ["a", "b", "c"]}`), "tags[1]", "z")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tags": ["a", "z", "c"]}`, string(out))
+}
+
+func TestSetBytesAppendsWithNegativeOneIndex(t *testing.T) {
+	out, err := SetBytes([]byte(`{"tags": ["a", "b"]}`), "tags[-1]", "c")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tags": ["a", "b", "c"]}`, string(out))
+}
+
+func TestSetBytesFallsBackWhenPathDoesNotExist(t *testing.T) {
+	out, err := SetBytes([]byte(`{"a": 1}`), "missing.field", 5)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": 1, "missing": {"field": 5}}`, string(out))
+}
+
+func TestSetBytesPropagatesInvalidPathError(t *testing.T) {
+	_, err := SetBytes([]byte(`{"a": 1}`), "a[*]", 5)
+	require.Error(t, err)
+}
+
+func TestDeleteBytesMiddleField(t *testing.T) {
+	out, err := DeleteBytes([]byte(`{"a": 1, "b": 2, "c": 3}`), "b")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": 1, "c": 3}`, string(out))
+}
+
+func TestDeleteBytesLastField(t *testing.T) {
+	out, err := DeleteBytes([]byte(`{"a": 1, "b": 2, "c": 3}`), "c")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": 1, "b": 2}`, string(out))
+}
+
+func TestDeleteBytesFirstField(t *testing.T) {
+	out, err := DeleteBytes([]byte(`{"a": 1, "b": 2, "c": 3}`), "a")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"b": 2, "c": 3}`, string(out))
+}
+
+func TestDeleteBytesArrayElementPreservesOrder(t *testing.T) {
+	out, err := DeleteBytes([]byte(`[1, 2, 3]`), "[1]")
+	require.NoError(t, err)
+	require.JSONEq(t, `[1, 3]`, string(out))
+}
+
+func TestDeleteBytesMissingFieldFallsBack(t *testing.T) {
+	out, err := DeleteBytes([]byte(`{"a": 1}`), "missing")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": 1}`, string(out))
+}
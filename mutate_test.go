@@ -0,0 +1,104 @@
+package luna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapSetCreatesIntermediateObjects(t *testing.T) {
+	m := MapFromBytes([]byte(`{"user": {}}`))
+	m = m.Set("user.address.zip", "10001")
+	require.NoError(t, m.Err())
+	zip, err := m.Map("user").Map("address").String("zip")
+	require.NoError(t, err)
+	require.Equal(t, "10001", zip)
+}
+
+func TestMapSetBareKey(t *testing.T) {
+	m := MapFromBytes([]byte(`{}`))
+	m = m.Set("name", "Ada")
+	name, err := m.String("name")
+	require.NoError(t, err)
+	require.Equal(t, "Ada", name)
+}
+
+func TestMapSetArrayIndex(t *testing.T) {
+	m := MapFromBytes([]byte(`{"tags": ["a", "b"]}`))
+	m = m.Set("tags[1]", "c")
+	tag, err := m.Array("tags").String(1)
+	require.NoError(t, err)
+	require.Equal(t, "c", tag)
+}
+
+func TestMapDeleteField(t *testing.T) {
+	m := MapFromBytes([]byte(`{"a": 1, "b": 2}`))
+	m = m.Delete("a")
+	has, err := m.Has("a")
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestMapDeleteArrayElementPreservesOrder(t *testing.T) {
+	m := MapFromBytes([]byte(`{"tags": ["a", "b", "c"]}`))
+	m = m.Delete("tags[1]")
+	arr := m.Array("tags").MustInner()
+	require.Equal(t, []interface{}{"a", "c"}, arr)
+}
+
+func TestMapAppendCreatesArray(t *testing.T) {
+	m := MapFromBytes([]byte(`{}`))
+	m = m.Append("tags", "a").Append("tags", "b")
+	arr := m.Array("tags").MustInner()
+	require.Equal(t, []interface{}{"a", "b"}, arr)
+}
+
+func TestMapSetPropagatesError(t *testing.T) {
+	m := MapFromBytes([]byte(`{"a": 1}`))
+	m = m.Set("a.b", "x")
+	require.Error(t, m.Err())
+}
+
+func TestMapSetReflectsInInner(t *testing.T) {
+	m := MapFromBytes([]byte(`{}`)).Set("id", 42)
+	inner, err := m.Inner()
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"id": 42}, inner)
+}
+
+func TestMapSetNegativeOneIndexAppends(t *testing.T) {
+	m := MapFromBytes([]byte(`{"tags": ["a", "b"]}`))
+	m = m.Set("tags[-1]", "c")
+	require.NoError(t, m.Err())
+	require.Equal(t, []interface{}{"a", "b", "c"}, m.Array("tags").MustInner())
+}
+
+func TestMapSetNegativeOneIndexCreatesMissingArray(t *testing.T) {
+	m := MapFromBytes([]byte(`{}`))
+	m = m.Set("rows[-1]", "x")
+	require.NoError(t, m.Err())
+	require.Equal(t, []interface{}{"x"}, m.Array("rows").MustInner())
+}
+
+func TestMapSetNegativeOneIndexNestedCreatesRowsAndColumns(t *testing.T) {
+	m := MapFromBytes([]byte(`{}`))
+	m = m.Set("rows[-1][-1]", "x")
+	require.NoError(t, m.Err())
+	require.Equal(t, []interface{}{[]interface{}{"x"}}, m.Array("rows").MustInner())
+}
+
+func TestMapBytesSerializesMutatedTree(t *testing.T) {
+	m := MapFromBytes([]byte(`{"a": 1}`))
+	m = m.Set("b", "two").Delete("a")
+	b, err := m.Bytes()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"b": "two"}`, string(b))
+}
+
+func TestArrayBytesSerializesMutatedTree(t *testing.T) {
+	a := ArrayFromBytes([]byte(`[1, 2, 3]`))
+	a = a.Set("[-1]", 4).Delete("[0]")
+	b, err := a.Bytes()
+	require.NoError(t, err)
+	require.JSONEq(t, `[2, 3, 4]`, string(b))
+}
@@ -0,0 +1,135 @@
+package luna
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// maxInt64Float is float64(math.MaxInt64) rounded up to the nearest representable value
+// (2^63), which is exactly one past the real maximum; a valid int64 is always < this bound.
+const maxInt64Float = float64(math.MaxInt64)
+
+// toInt64 accepts a float64, a json.Number, or a string containing a number (many upstream
+// APIs quote large 64-bit ids to survive JavaScript's float64 range), and returns a range
+// error for values outside int64 or non-integer floats like 9.345.
+func toInt64(v interface{}) (int64, error) {
+	switch t := v.(type) {
+	case float64:
+		return floatToInt64(t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", string(t))
+		}
+		return floatToInt64(f)
+	case string:
+		if i, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", t)
+		}
+		return floatToInt64(f)
+	default:
+		return 0, fmt.Errorf("value was a %T, not a number", v)
+	}
+}
+
+func floatToInt64(f float64) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("value %v is not an integer", f)
+	}
+	if f < float64(math.MinInt64) || f >= maxInt64Float {
+		return 0, fmt.Errorf("value %v is out of range for int64", f)
+	}
+	return int64(f), nil
+}
+
+// toUint64 accepts a float64, a json.Number, or a string containing a number. See toInt64.
+func toUint64(v interface{}) (uint64, error) {
+	switch t := v.(type) {
+	case float64:
+		return floatToUint64(t)
+	case json.Number:
+		if u, err := t.Int64(); err == nil && u >= 0 {
+			return uint64(u), nil
+		}
+		if u, err := strconv.ParseUint(string(t), 10, 64); err == nil {
+			return u, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", string(t))
+		}
+		return floatToUint64(f)
+	case string:
+		if u, err := strconv.ParseUint(t, 10, 64); err == nil {
+			return u, nil
+		}
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", t)
+		}
+		return floatToUint64(f)
+	default:
+		return 0, fmt.Errorf("value was a %T, not a number", v)
+	}
+}
+
+func floatToUint64(f float64) (uint64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("value %v is not an integer", f)
+	}
+	if f < 0 || f >= float64(math.MaxUint64) {
+		return 0, fmt.Errorf("value %v is out of range for uint64", f)
+	}
+	return uint64(f), nil
+}
+
+// Number is a json.Number-like sentinel that accepts either a bare or a quoted number when
+// used as a struct field with encoding/json, for APIs that mix quoted and unquoted numerics
+// (e.g. a quoted 64-bit id alongside an unquoted count) in the same payload.
+type Number string
+
+// UnmarshalJSON accepts either a JSON number or a JSON string containing one.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		var unquoted string
+		if err := json.Unmarshal(data, &unquoted); err != nil {
+			return err
+		}
+		s = unquoted
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return fmt.Errorf("luna.Number: %q is not a number", s)
+	}
+	*n = Number(s)
+	return nil
+}
+
+// MarshalJSON emits the number unquoted, mirroring json.Number.
+func (n Number) MarshalJSON() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// String returns the number's textual representation.
+func (n Number) String() string {
+	return string(n)
+}
+
+// Int64 parses the number as an int64.
+func (n Number) Int64() (int64, error) {
+	return toInt64(string(n))
+}
+
+// Float64 parses the number as a float64.
+func (n Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
@@ -0,0 +1,86 @@
+package luna
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapInt64AcceptsQuotedId(t *testing.T) {
+	m := MapFromBytes([]byte(`{"id": "9223372036854775000", "count": 5}`))
+	id, err := m.Int64("id")
+	require.NoError(t, err)
+	require.Equal(t, int64(9223372036854775000), id)
+
+	count, err := m.Int64("count")
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
+
+func TestMapInt64RejectsNonIntegerFloat(t *testing.T) {
+	m := MapFromBytes([]byte(`{"value": 9.345}`))
+	_, err := m.Int64("value")
+	require.Error(t, err)
+}
+
+func TestMapInt64RejectsOutOfRange(t *testing.T) {
+	m := MapFromBytes([]byte(`{"value": 1e30}`))
+	_, err := m.Int64("value")
+	require.Error(t, err)
+}
+
+func TestMapUint64RejectsNegative(t *testing.T) {
+	m := MapFromBytes([]byte(`{"value": -1}`))
+	_, err := m.Uint64("value")
+	require.Error(t, err)
+}
+
+func TestMapUint64AcceptsMaxUint64AsNumber(t *testing.T) {
+	m := MapFromBytesWith([]byte(`{"id": 18446744073709551615}`), NumberDecoder())
+	id, err := m.Uint64("id")
+	require.NoError(t, err)
+	require.Equal(t, uint64(18446744073709551615), id)
+}
+
+func TestMapMustInt64AndMustUint64(t *testing.T) {
+	m := MapFromBytes([]byte(`{"id": "42", "count": 7}`))
+	require.Equal(t, int64(42), m.MustInt64("id"))
+	require.Equal(t, uint64(7), m.MustUint64("count"))
+}
+
+func TestArrayInt64AndUint64(t *testing.T) {
+	a := ArrayFromBytes([]byte(`["42", 7]`))
+	i, err := a.Int64(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), i)
+	require.Equal(t, uint64(7), a.MustUint64(1))
+}
+
+func TestNumberUnmarshalsQuotedAndUnquoted(t *testing.T) {
+	type payload struct {
+		Quoted   Number `json:"quoted"`
+		Unquoted Number `json:"unquoted"`
+	}
+	var p payload
+	require.NoError(t, json.Unmarshal([]byte(`{"quoted":"123","unquoted":456}`), &p))
+
+	qi, err := p.Quoted.Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(123), qi)
+
+	ui, err := p.Unquoted.Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(456), ui)
+}
+
+func TestNumberMarshalsUnquoted(t *testing.T) {
+	b, err := json.Marshal(Number("42"))
+	require.NoError(t, err)
+	require.Equal(t, "42", string(b))
+}
+
+func TestNumberUnmarshalRejectsNonNumeric(t *testing.T) {
+	var n Number
+	require.Error(t, json.Unmarshal([]byte(`"not a number"`), &n))
+}
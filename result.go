@@ -0,0 +1,222 @@
+package luna
+
+import "fmt"
+
+// Result is the node-set produced by a JSONPath Query, which may hold zero, one, or many matches.
+type Result struct {
+	nodes []queryNode
+	err   error
+}
+
+// Query evaluates a JSONPath expression (e.g. `$.people[*].name`) against the map and
+// returns the matching node-set, or a propagated error.
+func (m Map) Query(expr string) Result {
+	if m.err != nil {
+		return Result{err: m.err}
+	}
+	return runQuery(expr, queryNode{m.m, m.path})
+}
+
+// MustQuery evaluates a JSONPath expression, or panics if there was an error.
+func (m Map) MustQuery(expr string) Result {
+	r := m.Query(expr)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+// Query evaluates a JSONPath expression (e.g. `$[*].name`) against the array and
+// returns the matching node-set, or a propagated error.
+func (a Array) Query(expr string) Result {
+	if a.err != nil {
+		return Result{err: a.err}
+	}
+	return runQuery(expr, queryNode{a.a, a.path})
+}
+
+// MustQuery evaluates a JSONPath expression, or panics if there was an error.
+func (a Array) MustQuery(expr string) Result {
+	r := a.Query(expr)
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r
+}
+
+func runQuery(expr string, root queryNode) Result {
+	cq, err := compileQuery(expr)
+	if err != nil {
+		return Result{err: err}
+	}
+	nodes, err := evalSteps(cq.steps, []queryNode{root})
+	if err != nil {
+		return Result{err: err}
+	}
+	return Result{nodes: nodes}
+}
+
+// Query re-evaluates a JSONPath expression rooted at each node already matched by this
+// Result, so a complex lookup can be built up one step at a time.
+func (r Result) Query(expr string) Result {
+	if r.err != nil {
+		return r
+	}
+	cq, err := compileQuery(expr)
+	if err != nil {
+		return Result{err: err}
+	}
+	nodes, err := evalSteps(cq.steps, r.nodes)
+	if err != nil {
+		return Result{err: err}
+	}
+	return Result{nodes: nodes}
+}
+
+// Err returns any error that was found up to this point
+func (r Result) Err() error {
+	return r.err
+}
+
+// Len returns the number of values matched by the query, or a propagated error
+func (r Result) Len() (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return len(r.nodes), nil
+}
+
+// MustLen returns the number of values matched by the query, or panics if there was an error
+func (r Result) MustLen() int {
+	n, err := r.Len()
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// AsMap returns the single matched value as a Map; it is an error unless the query matched
+// exactly one object.
+func (r Result) AsMap() (Map, error) {
+	if r.err != nil {
+		return Map{err: r.err}, r.err
+	}
+	n, err := r.single()
+	if err != nil {
+		return Map{err: err}, err
+	}
+	m, ok := n.value.(map[string]interface{})
+	if !ok {
+		err := fmt.Errorf("item at path %s was a %T, not a map", n.path, n.value)
+		return Map{err: err}, err
+	}
+	return Map{m, n.path, nil}, nil
+}
+
+// MustAsMap returns the single matched value as a Map, or panics if there was an error
+func (r Result) MustAsMap() Map {
+	m, err := r.AsMap()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// AsArray bundles every value matched by the query into an Array, preserving match order.
+func (r Result) AsArray() (Array, error) {
+	if r.err != nil {
+		return Array{err: r.err}, r.err
+	}
+	values := make([]interface{}, len(r.nodes))
+	for i, n := range r.nodes {
+		values[i] = n.value
+	}
+	return Array{values, "$", nil}, nil
+}
+
+// MustAsArray bundles every value matched by the query into an Array, or panics if there was an error
+func (r Result) MustAsArray() Array {
+	a, err := r.AsArray()
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// AsString returns the single matched value as a string; it is an error unless the query
+// matched exactly one string.
+func (r Result) AsString() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	n, err := r.single()
+	if err != nil {
+		return "", err
+	}
+	s, ok := n.value.(string)
+	if !ok {
+		return "", fmt.Errorf("item at path %s was a %T, not a string", n.path, n.value)
+	}
+	return s, nil
+}
+
+// MustAsString returns the single matched value as a string, or panics if there was an error
+func (r Result) MustAsString() string {
+	s, err := r.AsString()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// AsFloat returns the single matched value as a float64; it is an error unless the query
+// matched exactly one number. This is the usual way to read a Get("...#") array length.
+func (r Result) AsFloat() (float64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.single()
+	if err != nil {
+		return 0, err
+	}
+	f, ok := toFloat(n.value)
+	if !ok {
+		return 0, fmt.Errorf("item at path %s was a %T, not a float", n.path, n.value)
+	}
+	return f, nil
+}
+
+// MustAsFloat returns the single matched value as a float64, or panics if there was an error
+func (r Result) MustAsFloat() float64 {
+	f, err := r.AsFloat()
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// AsInt returns the single matched value as an int; it is an error unless the query matched
+// exactly one number.
+func (r Result) AsInt() (int, error) {
+	f, err := r.AsFloat()
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+// MustAsInt returns the single matched value as an int, or panics if there was an error
+func (r Result) MustAsInt() int {
+	i, err := r.AsInt()
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func (r Result) single() (queryNode, error) {
+	if len(r.nodes) != 1 {
+		return queryNode{}, fmt.Errorf("expected exactly 1 query result, got %d", len(r.nodes))
+	}
+	return r.nodes[0], nil
+}
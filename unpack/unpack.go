@@ -0,0 +1,294 @@
+// Package unpack decodes tagged-union JSON: trees where any object carrying a discriminator
+// field (e.g. "op": "filter") should be instantiated as its own registered Go type, possibly
+// nested arbitrarily deep inside other registered types' own fields or inside arrays.
+//
+// Each registered type is expected to own its shape the normal Go way (struct tags, a custom
+// UnmarshalJSON, etc); unpack's job is only to recognize which concrete type a given object
+// is, swap in already-unpacked values for any nested unions it contains, and then hand the
+// result to encoding/json to decode for real.
+package unpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mikecoop83/luna"
+)
+
+// Unpacker instantiates the registered Go type for any JSON object whose tagKey field
+// matches a registered tag.
+type Unpacker struct {
+	tagKey   string
+	registry map[string]reflect.Type
+}
+
+// New creates an Unpacker keyed by tagKey, the JSON field name whose value selects a
+// registered type (e.g. "op" for `{"op": "filter", ...}`).
+func New(tagKey string) *Unpacker {
+	return &Unpacker{tagKey: tagKey, registry: map[string]reflect.Type{}}
+}
+
+// Register associates tag with the Go type of prototype (the value itself is only used for
+// its type; its fields are ignored), so any object whose tagKey field equals tag is decoded
+// into a fresh instance of that type.
+func (u *Unpacker) Register(tag string, prototype interface{}) {
+	u.registry[tag] = reflect.TypeOf(prototype)
+}
+
+// Unmarshal parses data as a tagged union member, or as an array of them, and recursively
+// instantiates every registered type it contains.
+func (u *Unpacker) Unmarshal(data []byte) (interface{}, error) {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return u.UnmarshalArray(luna.ArrayFromBytes(data))
+	}
+	return u.UnmarshalObject(luna.MapFromBytes(data))
+}
+
+// UnmarshalObject decodes an already-parsed Map as a tagged union member. m must carry the
+// discriminator field; any nested object that carries it too (at any depth, including inside
+// arrays) is instantiated the same way.
+func (u *Unpacker) UnmarshalObject(m luna.Map) (interface{}, error) {
+	if err := m.Err(); err != nil {
+		return nil, err
+	}
+	hasTag, err := m.Has(u.tagKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTag {
+		return nil, fmt.Errorf("unpack: discriminator field '%s' not found at path %s, registered tags: %s",
+			u.tagKey, m.Path(), strings.Join(u.sortedTags(), ", "))
+	}
+	raw, err := m.Inner()
+	if err != nil {
+		return nil, err
+	}
+	return u.process(raw, fieldPath(m.Path()))
+}
+
+// UnmarshalArray decodes every element of an already-parsed Array as a tagged union member.
+func (u *Unpacker) UnmarshalArray(a luna.Array) ([]interface{}, error) {
+	if err := a.Err(); err != nil {
+		return nil, err
+	}
+	n, err := a.Len()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		v, err := u.UnmarshalObject(a.Map(i))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func (u *Unpacker) sortedTags() []string {
+	tags := make([]string, 0, len(u.registry))
+	for t := range u.registry {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// process walks an already-decoded Go value (as produced by Map.Inner/Array.Inner),
+// recursively instantiating any map that carries the discriminator field. Maps without it
+// are left as plain map[string]interface{}, so only the parts of the tree that opt in to
+// being a union member are affected.
+func (u *Unpacker) process(v interface{}, p fieldPath) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return u.processMap(t, p)
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			pv, err := u.process(item, p.appendIndex(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = pv
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func (u *Unpacker) processMap(m map[string]interface{}, p fieldPath) (interface{}, error) {
+	processed := make(map[string]interface{}, len(m))
+	for key, val := range m {
+		pv, err := u.process(val, p.appendKey(key))
+		if err != nil {
+			return nil, err
+		}
+		processed[key] = pv
+	}
+	tagVal, hasTag := processed[u.tagKey]
+	if !hasTag {
+		return processed, nil
+	}
+	tag, ok := tagVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("unpack: discriminator field '%s' at path %s was a %T, not a string", u.tagKey, p, tagVal)
+	}
+	return u.instantiate(tag, processed, p)
+}
+
+// jsonUnmarshalerType lets instantiate detect a registered type's own custom UnmarshalJSON, so
+// types like AST nodes that validate or post-process themselves on decode still get a chance
+// to do so - the reflection-based assignStruct path below is only a fallback for plain structs
+// that rely on encoding/json's default field-matching behavior.
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (u *Unpacker) instantiate(tag string, processed map[string]interface{}, p fieldPath) (interface{}, error) {
+	t, ok := u.registry[tag]
+	if !ok {
+		return nil, fmt.Errorf("unpack: unknown %s %q at path %s, registered tags: %s",
+			u.tagKey, tag, p, strings.Join(u.sortedTags(), ", "))
+	}
+	instPtr := reflect.New(t)
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		data, err := json.Marshal(processed)
+		if err != nil {
+			return nil, err
+		}
+		if err := instPtr.Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("unpack: decoding %s %q at path %s: %w", u.tagKey, tag, p, err)
+		}
+		return instPtr.Elem().Interface(), nil
+	}
+	if err := assignStruct(instPtr.Elem(), processed); err != nil {
+		return nil, fmt.Errorf("unpack: decoding %s %q at path %s: %w", u.tagKey, tag, p, err)
+	}
+	return instPtr.Elem().Interface(), nil
+}
+
+// assignStruct populates the exported fields of structVal (a registered union member type with
+// no custom UnmarshalJSON - see instantiate) from processed, a decoded JSON object in which any
+// nested union member has already been replaced by its own concrete Go value. It does not go
+// through encoding/json, because encoding/json can only decode raw bytes into interface{}-typed
+// fields generically - it has no way to preserve a value that's already been resolved to a
+// concrete type.
+func assignStruct(structVal reflect.Value, processed map[string]interface{}) error {
+	t := structVal.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		name, ok := fieldJSONName(f)
+		if !ok {
+			continue
+		}
+		val, ok := processed[name]
+		if !ok {
+			continue
+		}
+		if err := assignField(structVal.Field(i), val); err != nil {
+			return fmt.Errorf("field %q: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// fieldJSONName resolves the JSON object key that decodes into f, following encoding/json's
+// own tag rules: a `json:"-"` tag excludes the field, a `json:"name"` tag (optionally followed
+// by options such as ",omitempty") overrides the key, and otherwise the field's own name is
+// used verbatim.
+func fieldJSONName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if tag == "" {
+		return f.Name, true
+	}
+	if comma := strings.Index(tag, ","); comma >= 0 {
+		tag = tag[:comma]
+	}
+	if tag == "" {
+		return f.Name, true
+	}
+	return tag, true
+}
+
+// assignField assigns val, a value produced by process (so a map[string]interface{}, a
+// []interface{}, a concrete union member type, or a plain JSON scalar), into fieldVal. Values
+// already assignable to the field's type - including already-resolved union member types and
+// their slices - are set directly; everything else falls back to a scoped encoding/json round
+// trip, which is safe there since it was never a union member in the first place.
+func assignField(fieldVal reflect.Value, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	valRV := reflect.ValueOf(val)
+	if valRV.Type().AssignableTo(fieldVal.Type()) {
+		fieldVal.Set(valRV)
+		return nil
+	}
+	if items, ok := val.([]interface{}); ok && fieldVal.Kind() == reflect.Slice {
+		out := reflect.MakeSlice(fieldVal.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := assignField(out.Index(i), item); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		fieldVal.Set(out)
+		return nil
+	}
+	if f, ok := val.(float64); ok && isNumericKind(fieldVal.Kind()) {
+		return assignNumeric(fieldVal, f)
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, fieldVal.Addr().Interface())
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func assignNumeric(fieldVal reflect.Value, f float64) error {
+	switch fieldVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fieldVal.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fieldVal.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		fieldVal.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot assign number to %s", fieldVal.Kind())
+	}
+	return nil
+}
+
+// fieldPath mirrors luna's own internal path type (jsonpath-style "$['key'][0]" strings), so
+// errors raised while walking a Map's already-decoded Inner() value read the same as the
+// errors Map itself raises.
+type fieldPath string
+
+func (p fieldPath) appendKey(key string) fieldPath {
+	return p + fieldPath(fmt.Sprintf("['%s']", key))
+}
+
+func (p fieldPath) appendIndex(idx int) fieldPath {
+	return p + fieldPath(fmt.Sprintf("[%d]", idx))
+}
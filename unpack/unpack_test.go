@@ -0,0 +1,130 @@
+package unpack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikecoop83/luna"
+	"github.com/stretchr/testify/require"
+)
+
+type FilterOp struct {
+	Op    string `json:"op"`
+	Field string `json:"field"`
+}
+
+type SortOp struct {
+	Op  string `json:"op"`
+	By  string `json:"by"`
+	Asc bool   `json:"asc"`
+}
+
+type PipelineOp struct {
+	Op    string        `json:"op"`
+	Steps []interface{} `json:"steps"`
+}
+
+// LimitOp has a custom UnmarshalJSON that sets Validated, a sentinel with no corresponding JSON
+// field, so a test can tell whether that method ran at all.
+type LimitOp struct {
+	Op        string `json:"op"`
+	Count     int    `json:"count"`
+	Validated bool   `json:"-"`
+}
+
+func (l *LimitOp) UnmarshalJSON(data []byte) error {
+	type alias LimitOp
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*l = LimitOp(a)
+	l.Validated = true
+	return nil
+}
+
+func newOpsUnpacker() *Unpacker {
+	u := New("op")
+	u.Register("filter", FilterOp{})
+	u.Register("sort", SortOp{})
+	u.Register("pipeline", PipelineOp{})
+	u.Register("limit", LimitOp{})
+	return u
+}
+
+func TestUnmarshalSingleOp(t *testing.T) {
+	u := newOpsUnpacker()
+	v, err := u.Unmarshal([]byte(`{"op": "filter", "field": "name"}`))
+	require.NoError(t, err)
+	require.Equal(t, FilterOp{Op: "filter", Field: "name"}, v)
+}
+
+func TestUnmarshalArrayOfOps(t *testing.T) {
+	u := newOpsUnpacker()
+	v, err := u.Unmarshal([]byte(`[
+		{"op": "filter", "field": "name"},
+		{"op": "sort", "by": "score", "asc": true}
+	]`))
+	require.NoError(t, err)
+	ops, ok := v.([]interface{})
+	require.True(t, ok)
+	require.Equal(t, FilterOp{Op: "filter", Field: "name"}, ops[0])
+	require.Equal(t, SortOp{Op: "sort", By: "score", Asc: true}, ops[1])
+}
+
+func TestUnmarshalNestedUnion(t *testing.T) {
+	u := newOpsUnpacker()
+	v, err := u.Unmarshal([]byte(`{
+		"op": "pipeline",
+		"steps": [
+			{"op": "filter", "field": "name"},
+			{"op": "sort", "by": "score", "asc": false}
+		]
+	}`))
+	require.NoError(t, err)
+	pipeline, ok := v.(PipelineOp)
+	require.True(t, ok)
+	require.Equal(t, FilterOp{Op: "filter", Field: "name"}, pipeline.Steps[0])
+	require.Equal(t, SortOp{Op: "sort", By: "score", Asc: false}, pipeline.Steps[1])
+}
+
+func TestUnmarshalDispatchesToRegisteredTypesUnmarshalJSON(t *testing.T) {
+	u := newOpsUnpacker()
+	v, err := u.Unmarshal([]byte(`{"op": "limit", "count": 10}`))
+	require.NoError(t, err)
+	require.Equal(t, LimitOp{Op: "limit", Count: 10, Validated: true}, v)
+}
+
+func TestUnmarshalMissingDiscriminatorNamesRegisteredTags(t *testing.T) {
+	u := newOpsUnpacker()
+	_, err := u.Unmarshal([]byte(`{"field": "name"}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "op")
+	require.Contains(t, err.Error(), "filter")
+	require.Contains(t, err.Error(), "sort")
+	require.Contains(t, err.Error(), "$")
+}
+
+func TestUnmarshalUnknownTagNamesRegisteredTags(t *testing.T) {
+	u := newOpsUnpacker()
+	_, err := u.Unmarshal([]byte(`{"op": "join", "field": "name"}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "join")
+	require.Contains(t, err.Error(), "filter")
+	require.Contains(t, err.Error(), "sort")
+}
+
+func TestUnmarshalArrayElementMissingDiscriminatorNamesPath(t *testing.T) {
+	u := newOpsUnpacker()
+	_, err := u.Unmarshal([]byte(`[{"op": "filter", "field": "name"}, {"field": "oops"}]`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "[1]")
+}
+
+func TestUnmarshalObjectFromParsedMap(t *testing.T) {
+	u := newOpsUnpacker()
+	m := luna.MapFromBytes([]byte(`{"op": "sort", "by": "score", "asc": true}`))
+	v, err := u.UnmarshalObject(m)
+	require.NoError(t, err)
+	require.Equal(t, SortOp{Op: "sort", By: "score", Asc: true}, v)
+}